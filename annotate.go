@@ -0,0 +1,128 @@
+package wnram
+
+import (
+	"strings"
+	"unicode"
+)
+
+// A word of a part of speech tagged text
+type Token struct {
+	Text string
+	// The parts of speech the tagger allows, any if empty
+	POS PartOfSpeechList
+}
+
+// A token linked to the meaning Annotate chose for it
+type Annotation struct {
+	Token
+	// The zero Lookup (with an empty SynsetID) for skipped tokens
+	Sense Lookup
+}
+
+// English function words, which Annotate skips even where WordNet has an
+// unrelated lemma for them ("in" the inch, "a" the vitamin)
+var functionWords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`a an the and or but nor so yet if then than
+		as at by for from in into of off on onto out over to up upon with within
+		without about above after before below between during through under
+		i me my we us our you your he him his she her it its they them their
+		this that these those who whom whose which what
+		am is are was were be been being have has had do does did
+		will would shall should can could may might must not no`) {
+		functionWords[w] = true
+	}
+}
+
+// Link each content word of tokens to its most likely meaning, a simple
+// word sense disambiguation for POS tagged sentences.  Each token is
+// looked up (with morphology) as its allowed parts of speech and the
+// sense whose gloss, members and hypernyms' members share the most words
+// with the other tokens wins (the simplified Lesk algorithm), the more
+// frequent sense on ties.  The result has one annotation per token, in
+// order; function words, punctuation and words WordNet doesn't know are
+// left without a sense.
+func (h *Handle) Annotate(tokens []Token) []Annotation {
+	context := map[string]int{}
+	for _, t := range tokens {
+		if w := h.contextForm(t.Text); w != "" {
+			context[w]++
+		}
+	}
+
+	annotations := make([]Annotation, len(tokens))
+	for i, t := range tokens {
+		annotations[i].Token = t
+		if h.contextForm(t.Text) == "" {
+			continue
+		}
+		senses, err := h.sensesByFrequency(t.Text, t.POS)
+		if err != nil || len(senses) == 0 {
+			continue
+		}
+
+		// the token's own words don't count as context
+		own := map[string]bool{}
+		for _, w := range strings.Fields(normalize(t.Text)) {
+			own[h.contextForm(w)] = true
+		}
+
+		best, bestScore := 0, -1
+		for j, s := range senses {
+			if score := h.leskOverlap(s, context, own); score > bestScore {
+				best, bestScore = j, score
+			}
+		}
+		annotations[i].Sense = senses[best]
+	}
+	return annotations
+}
+
+// the number of context words in the signature of sense l: its gloss, its
+// members and its hypernyms' members
+func (h *Handle) leskOverlap(l Lookup, context map[string]int, own map[string]bool) int {
+	signature := map[string]bool{}
+	addWords := func(s string) {
+		for _, w := range strings.Fields(s) {
+			if w = h.contextForm(w); w != "" && !own[w] {
+				signature[w] = true
+			}
+		}
+	}
+	addWords(l.Gloss())
+	for _, m := range l.cluster.words {
+		addWords(m.word)
+	}
+	for _, rel := range l.cluster.relations {
+		if rel.rel&generalizations != 0 {
+			for _, m := range rel.target.words {
+				addWords(m.word)
+			}
+		}
+	}
+
+	overlap := 0
+	for w := range signature {
+		overlap += context[w]
+	}
+	return overlap
+}
+
+// the form a word is compared in for disambiguation: lower cased,
+// without surrounding punctuation and reduced to a noun or verb base form
+// where there is one.  Empty for function words and punctuation.
+func (h *Handle) contextForm(word string) string {
+	w := strings.TrimFunc(h.normalizeQuery(word), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if w == "" || functionWords[w] {
+		return ""
+	}
+	for _, pos := range []PartOfSpeech{Noun, Verb} {
+		if base := h.MorphWord(w, pos); base != "" {
+			return base
+		}
+	}
+	return w
+}
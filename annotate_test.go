@@ -0,0 +1,56 @@
+package wnram
+
+import (
+	"strings"
+	"testing"
+)
+
+// tokens for a sentence with the nouns and verbs tagged
+func tagged(sentence string, pos map[string]PartOfSpeech) []Token {
+	var tokens []Token
+	for _, w := range strings.Fields(sentence) {
+		t := Token{Text: w}
+		if p, ok := pos[w]; ok {
+			t.POS = PartOfSpeechList{p}
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+func TestAnnotate(t *testing.T) {
+	tests := []struct {
+		sentence string
+		pos      map[string]PartOfSpeech
+		gloss    string // expected in the gloss of the sense of bank
+	}{
+		{"I cashed my check at the bank", map[string]PartOfSpeech{"cashed": Verb, "check": Noun, "bank": Noun}, "financial institution"},
+		{"we fished from the grassy bank of the river", map[string]PartOfSpeech{"fished": Verb, "bank": Noun, "river": Noun}, "sloping land"},
+	}
+	for _, tt := range tests {
+		annotations := wnInstance.Annotate(tagged(tt.sentence, tt.pos))
+		for _, a := range annotations {
+			switch a.Text {
+			case "bank":
+				if !strings.Contains(a.Sense.Gloss(), tt.gloss) {
+					t.Errorf("%q: bank annotated as %q", tt.sentence, a.Sense.Gloss())
+				}
+			case "the", "at", "I", "we", "of", "from", "my":
+				if a.Sense.SynsetID() != "" {
+					t.Errorf("%q: function word %q annotated as %s", tt.sentence, a.Text, a.Sense.SynsetID())
+				}
+			}
+		}
+		if len(annotations) != len(strings.Fields(tt.sentence)) {
+			t.Errorf("expected one annotation per token, got %d", len(annotations))
+		}
+	}
+
+	got := wnInstance.Annotate([]Token{{Text: "xyzzyplugh"}, {Text: "!"}, {Text: "dogs", POS: PartOfSpeechList{Noun}}})
+	if got[0].Sense.SynsetID() != "" || got[1].Sense.SynsetID() != "" {
+		t.Errorf("unknown words and punctuation shouldn't be annotated")
+	}
+	if got[2].Sense.POS() != Noun || got[2].Sense.Word() != "dogs" {
+		t.Errorf("dogs annotated as %v", got[2].Sense)
+	}
+}
@@ -0,0 +1,108 @@
+package wnram
+
+import (
+	"container/list"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// A bounded, concurrency safe least recently used cache
+type lru[K comparable, V any] struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // of *lruEntry, most recently used at the front
+	entries map[K]*list.Element
+
+	hits, misses atomic.Uint64
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// newLRU returns a cache holding at most size entries, or nil if size
+// isn't positive.  All methods are no-ops on a nil cache.
+func newLRU[K comparable, V any](size int) *lru[K, V] {
+	if size <= 0 {
+		return nil
+	}
+	return &lru[K, V]{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[K]*list.Element, size),
+	}
+}
+
+func (c *lru[K, V]) get(key K) (value V, ok bool) {
+	if c == nil {
+		return value, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		c.misses.Add(1)
+		return value, false
+	}
+	c.hits.Add(1)
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry[K, V]).value, true
+}
+
+func (c *lru[K, V]) put(key K, value V) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key, value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+// purge drops every entry, keeping the hit and miss counts
+func (c *lru[K, V]) purge() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.entries)
+}
+
+func (c *lru[K, V]) stats() (hits, misses uint64) {
+	if c == nil {
+		return 0, 0
+	}
+	return c.hits.Load(), c.misses.Load()
+}
+
+// a cached LookupPage result
+type lookupPage struct {
+	results []Lookup
+	total   int
+}
+
+// the lookup cache key for crit: its search strings as given (results
+// report them as Word) and the set of parts of speech, whose order
+// doesn't matter
+func lookupCacheKey(crit Criteria) string {
+	pos := slices.Clone(crit.POS)
+	slices.Sort(pos)
+	return fmt.Sprintf("%q %q %v %d %d %d %d %d", crit.Matching, crit.MatchingAny, slices.Compact(pos), crit.SenseNumber, crit.Offset, crit.Limit, crit.PreloadRelations, crit.PreloadDepth)
+}
@@ -0,0 +1,124 @@
+package wnram
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLRU(t *testing.T) {
+	c := newLRU[string, int](2)
+	c.put("a", 1)
+	c.put("b", 2)
+	if v, ok := c.get("a"); !ok || v != 1 {
+		t.Errorf("get(a) = %d, %v", v, ok)
+	}
+	c.put("c", 3) // evicts b, the least recently used
+	if _, ok := c.get("b"); ok {
+		t.Errorf("b should have been evicted")
+	}
+	if v, ok := c.get("c"); !ok || v != 3 {
+		t.Errorf("get(c) = %d, %v", v, ok)
+	}
+	if hits, misses := c.stats(); hits != 2 || misses != 1 {
+		t.Errorf("stats() = %d hits, %d misses", hits, misses)
+	}
+	c.purge()
+	if _, ok := c.get("a"); ok {
+		t.Errorf("a should have been purged")
+	}
+
+	disabled := newLRU[string, int](0)
+	disabled.put("a", 1)
+	if _, ok := disabled.get("a"); ok {
+		t.Errorf("a disabled cache shouldn't store anything")
+	}
+}
+
+func TestLookupCache(t *testing.T) {
+	h, err := NewWithOptions(sourceCodeRelPath(PathToWordnetDataFiles), Options{LookupCacheSize: 10})
+	if err != nil {
+		t.Fatalf("can't load: %s", err)
+	}
+
+	want, _ := wnInstance.Lookup(Criteria{Matching: "dogs", POS: PartOfSpeechList{Noun, Verb}})
+	for _, pos := range []PartOfSpeechList{{Noun, Verb}, {Verb, Noun}, {Noun, Verb, Noun}} {
+		found, err := h.Lookup(Criteria{Matching: "dogs", POS: pos})
+		if err != nil || len(found) != len(want) {
+			t.Fatalf("Lookup(dogs, %v) = %d results (%v), want %d", pos, len(found), err, len(want))
+		}
+		for j := range found {
+			if found[j].Canonical() != want[j].Canonical() || found[j].Word() != "dogs" {
+				t.Errorf("result %d of %v differs: %s", j, pos, found[j].Canonical())
+			}
+		}
+		// callers may change their results without affecting the cache
+		found[0] = Lookup{}
+	}
+	if s := h.Stats(); s.LookupCacheHits != 2 || s.LookupCacheMisses != 1 {
+		t.Errorf("unexpected cache stats %+v", s)
+	}
+
+	if found, _ := h.Lookup(Criteria{Matching: "florbs"}); len(found) != 0 {
+		t.Fatalf("unexpected results for florbs: %v", found)
+	}
+	h.AddException(Noun, "florbs", "dog")
+	if found, _ := h.Lookup(Criteria{Matching: "florbs"}); len(found) == 0 {
+		t.Errorf("stale cache entry after AddException")
+	}
+
+	if s := wnInstance.Stats(); s.LookupCacheHits != 0 || s.LookupCacheMisses != 0 {
+		t.Errorf("unexpected stats without a cache %+v", s)
+	}
+}
+
+func TestMorphCache(t *testing.T) {
+	h, err := NewWithOptions(sourceCodeRelPath(PathToWordnetDataFiles), Options{MorphCacheSize: 100})
+	if err != nil {
+		t.Fatalf("can't load: %s", err)
+	}
+
+	for range 3 {
+		if got := h.MorphWord("dogs", Noun); got != "dog" {
+			t.Errorf("MorphWord(dogs) = %q", got)
+		}
+	}
+	if s := h.Stats(); s.MorphCacheHits != 2 || s.MorphCacheMisses != 1 {
+		t.Errorf("unexpected cache stats %+v", s)
+	}
+
+	h.AddException(Noun, "dogs", "hound")
+	if got := h.MorphWord("dogs", Noun); got != "hound" {
+		t.Errorf("stale cache entry after AddException: %q", got)
+	}
+
+	if s := wnInstance.Stats(); s.MorphCacheHits != 0 || s.MorphCacheMisses != 0 || s.Synsets != 117791 {
+		t.Errorf("unexpected stats without a cache %+v", s)
+	}
+}
+
+// a stream of tokens with a realistic amount of repetition
+var benchmarkTokens = strings.Fields(strings.Repeat(
+	"the dogs were running across the fields while the children played with their toys "+
+		"and the ladies watched the horses eating apples near the houses ", 50))
+
+func benchmarkMorphWord(b *testing.B, opts Options) {
+	h, err := NewWithOptions(sourceCodeRelPath(PathToWordnetDataFiles), opts)
+	if err != nil {
+		b.Fatalf("can't load: %s", err)
+	}
+	b.ResetTimer()
+	for range b.N {
+		for _, tok := range benchmarkTokens {
+			h.MorphWord(tok, Noun)
+			h.MorphWord(tok, Verb)
+		}
+	}
+}
+
+func BenchmarkMorphWordUncached(b *testing.B) {
+	benchmarkMorphWord(b, Options{})
+}
+
+func BenchmarkMorphWordCached(b *testing.B) {
+	benchmarkMorphWord(b, Options{MorphCacheSize: 1000})
+}
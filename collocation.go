@@ -0,0 +1,60 @@
+package wnram
+
+import (
+	"slices"
+	"strings"
+)
+
+// The pos synsets having a collocation containing word as one of its
+// words as a member, e.g. "ice cream" and "cream cheese" for "cream".
+// Each result is searched for as the collocation, they are ordered by it
+// alphabetically and then as Lookup orders them.  Word is matched as is,
+// without morphology.  Without Options.CollocationIndex this scans all
+// lemmas, some 150,000, on every call.  Nil for handles created with
+// NewIndexOnly.
+func (h *Handle) Collocations(word string, pos PartOfSpeech) []Lookup {
+	word = h.normalizeQuery(word)
+	if word == "" || h.indexOnly {
+		return nil
+	}
+
+	var lemmas []string
+	if h.collocations != nil {
+		lemmas = h.collocations[word]
+	} else {
+		for lemma := range h.index {
+			if strings.Contains(lemma, " ") && slices.Contains(strings.Split(lemma, " "), word) {
+				lemmas = append(lemmas, lemma)
+			}
+		}
+		slices.Sort(lemmas)
+	}
+
+	var found []Lookup
+	for _, lemma := range lemmas {
+		for i, c := range h.index[lemma] {
+			// a synset is indexed once per spelling variant of lemma
+			if c.pos == pos && (i == 0 || h.index[lemma][i-1] != c) {
+				found = append(found, Lookup{word: lemma, cluster: c})
+			}
+		}
+	}
+	return found
+}
+
+func (h *Handle) buildCollocationIndex() {
+	h.collocations = map[string][]string{}
+	for lemma := range h.index {
+		words := strings.Split(lemma, " ")
+		if len(words) < 2 {
+			continue
+		}
+		slices.Sort(words)
+		for _, w := range slices.Compact(words) {
+			h.collocations[w] = append(h.collocations[w], lemma)
+		}
+	}
+	for _, lemmas := range h.collocations {
+		slices.Sort(lemmas)
+	}
+}
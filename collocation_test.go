@@ -0,0 +1,48 @@
+package wnram
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCollocations(t *testing.T) {
+	scanned := wnInstance.Collocations("Cream", Noun)
+	var words []string
+	for _, c := range scanned {
+		if !slices.Contains(strings.Split(c.Word(), " "), "cream") {
+			t.Errorf("%q doesn't contain cream", c.Word())
+		}
+		if c.POS() != Noun {
+			t.Errorf("%s isn't a noun", c.Canonical())
+		}
+		words = append(words, c.Word())
+	}
+	if !setContains(words, []string{"ice cream", "cream cheese", "sour cream"}) || slices.Contains(words, "cream") {
+		t.Errorf("unexpected collocations %v", words)
+	}
+	if !slices.IsSorted(words) {
+		t.Errorf("collocations not sorted: %v", words)
+	}
+
+	h, err := NewWithOptions(sourceCodeRelPath(PathToWordnetDataFiles), Options{CollocationIndex: true})
+	if err != nil {
+		t.Fatalf("can't load: %s", err)
+	}
+	indexed := h.Collocations("cream", Noun)
+	if len(indexed) != len(scanned) {
+		t.Fatalf("got %d collocations with the index, %d without", len(indexed), len(scanned))
+	}
+	for i := range indexed {
+		if indexed[i].Word() != scanned[i].Word() || indexed[i].SynsetID() != scanned[i].SynsetID() {
+			t.Errorf("result %d differs: %s and %s", i, indexed[i].Canonical(), scanned[i].Canonical())
+		}
+	}
+
+	if got := wnInstance.Collocations("cream", Adverb); len(got) != 0 {
+		t.Errorf("expected no adverb collocations, got %v", got)
+	}
+	if got := h.Collocations("xyzzyplugh", Noun); len(got) != 0 {
+		t.Errorf("expected no collocations for an unknown word, got %v", got)
+	}
+}
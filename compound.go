@@ -0,0 +1,51 @@
+package wnram
+
+import "unicode/utf8"
+
+// the shortest part SplitCompound will split off; single letters are
+// lemmas too ("a", "e") but would allow nonsense segmentations
+const minCompoundPart = 2
+
+// Split a closed compound such as "firetruck" or "blackboard" into
+// dictionary words ("fire", "truck"), preferring the segmentation into
+// the fewest words and, among those, the one with the longest leading
+// words.  Every part is a lemma of at least two letters, of any part of
+// speech, and there are always at least two parts, even if word is a
+// lemma itself.  Nil if no segmentation exists.
+func (h *Handle) SplitCompound(word string) []string {
+	s := h.normalizeQuery(word)
+
+	// parts[i] is the best number of parts for s[i:], next[i] where the
+	// first of them ends (zero if s[i:] can't be segmented)
+	parts := make([]int, len(s)+1)
+	next := make([]int, len(s)+1)
+	for i := len(s) - 1; i >= 0; i-- {
+		if !utf8.RuneStart(s[i]) {
+			continue
+		}
+		for j := len(s); j > i; j-- {
+			if j < len(s) && (!utf8.RuneStart(s[j]) || next[j] == 0) {
+				continue
+			}
+			if utf8.RuneCountInString(s[i:j]) < minCompoundPart || h.index[s[i:j]] == nil {
+				continue
+			}
+			// the whole word doesn't count as a split of itself
+			if i == 0 && j == len(s) {
+				continue
+			}
+			if n := parts[j] + 1; next[i] == 0 || n < parts[i] {
+				parts[i], next[i] = n, j
+			}
+		}
+	}
+	if next[0] == 0 {
+		return nil
+	}
+
+	var split []string
+	for i := 0; i < len(s); i = next[i] {
+		split = append(split, s[i:next[i]])
+	}
+	return split
+}
@@ -0,0 +1,41 @@
+package wnram
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitCompound(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected []string
+	}{
+		{"firetruck", []string{"fire", "truck"}},
+		{"blackboard", []string{"black", "board"}},
+		{"Doghouse", []string{"dog", "house"}},
+		{"icecreamtruck", []string{"icecream", "truck"}},
+		{"xqzvw", nil},
+		{"dog", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := wnInstance.SplitCompound(tt.word); !slices.Equal(got, tt.expected) {
+			t.Errorf("SplitCompound(%q) = %q; want %q", tt.word, got, tt.expected)
+		}
+	}
+
+	// fewer parts win over a longer first part
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 01 abc 0 000 | x\n" +
+			"00000002 03 n 01 de 0 000 | x\n" +
+			"00000003 03 n 01 fg 0 000 | x\n" +
+			"00000004 03 n 01 ab 0 000 | x\n" +
+			"00000005 03 n 01 cdefg 0 000 | x\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	if got := h.SplitCompound("abcdefg"); !slices.Equal(got, []string{"ab", "cdefg"}) {
+		t.Errorf("SplitCompound(abcdefg) = %q; want [ab cdefg]", got)
+	}
+}
@@ -0,0 +1,155 @@
+package wnram
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// the first line of a SaveDictionary blob
+const dictionaryMagic = "wnram dictionary 1"
+
+// a file of a dictionary blob, for exception and data files along with
+// the part of speech it holds
+type dictionaryFile struct {
+	name string
+	pos  PartOfSpeech
+}
+
+// the files a dictionary blob is made of, in the order they are written
+// and loaded
+var dictionaryFiles = []dictionaryFile{
+	{"adj.exc", Adjective},
+	{"adv.exc", Adverb},
+	{"data.adj", Adjective},
+	{"data.adv", Adverb},
+	{"data.noun", Noun},
+	{"data.verb", Verb},
+	{"index.sense", 0},
+	{"noun.exc", Noun},
+	{"verb.exc", Verb},
+}
+
+// Write a compact copy of the database without its relation graph to w:
+// the synsets' members and glosses, sense numbers and frequencies and the
+// morphological exceptions.  This is for applications that need a
+// dictionary rather than the semantic network; loaded with LoadDictionary
+// it supports Lookup, Gloss, the morphology methods and everything else
+// that doesn't follow relations.  Those don't fail but find nothing:
+// Related and RelatedFrom return no results and Walk only visits its
+// start.  Exceptions added with AddException and rules added with
+// AddSuffixRule are not saved.
+func (h *Handle) SaveDictionary(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, dictionaryMagic)
+	for _, f := range dictionaryFiles {
+		fmt.Fprintf(b, "@ %s\n", f.name)
+		switch {
+		case strings.HasPrefix(f.name, "data."):
+			for _, c := range h.db {
+				if c.pos == f.pos {
+					writeDictionarySynset(b, c)
+				}
+			}
+		case f.name == "index.sense":
+			for _, c := range h.db {
+				for i, m := range c.words {
+					if m.senseNumber > 0 || m.tagCount > 0 {
+						fmt.Fprintf(b, "%s %s %d %d\n", c.senseKey(i), c.debug, m.senseNumber, m.tagCount)
+					}
+				}
+			}
+		default:
+			exceptions := h.excByPOS[f.pos]
+			for _, surface := range slices.Sorted(maps.Keys(exceptions)) {
+				fmt.Fprintln(b, strings.ReplaceAll(surface+" "+strings.Join(exceptions[surface], " "), " ", "_"))
+			}
+		}
+	}
+	return b.Flush()
+}
+
+// writes c as a data file line without pointers, e.g. "02086723 05 n 01
+// dog 0 000 | a member of the genus Canis ..."
+func writeDictionarySynset(b *bufio.Writer, c *cluster) {
+	ssType := c.pos.letter()
+	if c.satellite {
+		ssType = "s"
+	}
+	fmt.Fprintf(b, "%s %02d %s %02x", c.debug, c.lexFile, ssType, len(c.words))
+	for _, m := range c.words {
+		lemma := strings.ReplaceAll(m.word, " ", "_")
+		if m.marker != "" {
+			lemma += "(" + m.marker + ")"
+		}
+		fmt.Fprintf(b, " %s %x", lemma, m.sense)
+	}
+	fmt.Fprintf(b, " 000 | %s\n", c.gloss)
+}
+
+// Initialize a database from a blob written by SaveDictionary.  See
+// SaveDictionary for what is (and isn't) available.
+func LoadDictionary(r io.Reader) (*Handle, error) {
+	return LoadDictionaryWithOptions(r, Options{})
+}
+
+// Like LoadDictionary, enabling the given optional features
+func LoadDictionaryWithOptions(r io.Reader, opts Options) (*Handle, error) {
+	var (
+		names    []string
+		sections = map[string][][]byte{}
+		current  string
+	)
+	err := inPlaceReadLine(r, func(data []byte, line, offset int64) error {
+		switch {
+		case line == 1:
+			if string(data) != dictionaryMagic {
+				return fmt.Errorf("not a dictionary blob")
+			}
+		case strings.HasPrefix(string(data), "@ "):
+			current = strings.TrimPrefix(string(data), "@ ")
+			if !slices.ContainsFunc(dictionaryFiles, func(f dictionaryFile) bool {
+				return f.name == current
+			}) {
+				return fmt.Errorf("line %d: unknown dictionary section %q", line, current)
+			}
+			names = append(names, current)
+		case current == "":
+			return fmt.Errorf("line %d: dictionary section expected", line)
+		default:
+			sections[current] = append(sections[current], slices.Clone(data))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("empty dictionary blob")
+	}
+
+	l := newLoader(opts.Logger)
+	for _, name := range names {
+		readLines := func(cb func([]byte, int64, int64) error) error {
+			for i, data := range sections[name] {
+				if err := cb(data, int64(i+1), 0); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := l.load(name, readLines); err != nil {
+			return nil, err
+		}
+	}
+	h, err := l.handle(opts)
+	if err != nil {
+		return nil, err
+	}
+	// without hypernyms every synset would look like a root
+	h.roots = map[PartOfSpeech][]*cluster{}
+	return h, nil
+}
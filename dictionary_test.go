@@ -0,0 +1,93 @@
+package wnram
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDictionaryRoundTrip(t *testing.T) {
+	var blob bytes.Buffer
+	if err := wnInstance.SaveDictionary(&blob); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("dictionary blob: %d bytes", blob.Len())
+	h, err := LoadDictionary(&blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := h.Stats().Synsets, wnInstance.Stats().Synsets; got != want {
+		t.Errorf("got %d synsets, want %d", got, want)
+	}
+	for _, c := range []struct {
+		word string
+		pos  PartOfSpeech
+	}{{"dog", Noun}, {"yummy", Adjective}, {"galore", Adjective}, {"walk", Verb}, {"geese", Noun}} {
+		want, _ := wnInstance.Lookup(Criteria{Matching: c.word, POS: PartOfSpeechList{c.pos}})
+		got, _ := h.Lookup(Criteria{Matching: c.word, POS: PartOfSpeechList{c.pos}})
+		if len(got) != len(want) || len(got) == 0 {
+			t.Fatalf("%s: got %d senses, want %d", c.word, len(got), len(want))
+		}
+		for i := range got {
+			if got[i].Canonical() != want[i].Canonical() || got[i].Gloss() != want[i].Gloss() ||
+				got[i].IsSatellite() != want[i].IsSatellite() || got[i].SyntacticMarker() != want[i].SyntacticMarker() {
+				t.Errorf("%s: got %s, want %s", c.word, got[i].Canonical(), want[i].Canonical())
+			}
+			if related := got[i].Related(^Relation(0)); len(related) != 0 {
+				t.Errorf("%s: expected no relations, got %d", c.word, len(related))
+			}
+			visited := 0
+			err := h.Walk(got[i], WalkOptions{Relations: ^Relation(0)}, func(Lookup, int, Relation) bool {
+				visited++
+				return true
+			})
+			if err != nil || visited != 1 {
+				t.Errorf("%s: walk visited %d synsets, %v; want only the start", c.word, visited, err)
+			}
+		}
+	}
+	if got := h.MorphWord("walked", Verb); got != "walk" {
+		t.Errorf("MorphWord(walked) = %q, want walk", got)
+	}
+	if roots := h.Roots(Noun); len(roots) != 0 {
+		t.Errorf("expected no roots, got %d", len(roots))
+	}
+}
+
+func TestDictionaryFrequencies(t *testing.T) {
+	fixture, err := New(writeDataDir(t, frequencyFixture))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	var blob bytes.Buffer
+	if err := fixture.SaveDictionary(&blob); err != nil {
+		t.Fatal(err)
+	}
+	h, err := LoadDictionary(&blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, _ := h.Lookup(Criteria{Matching: "automobile"})
+	if len(found) != 1 {
+		t.Fatalf("expected one result for automobile, got %d", len(found))
+	}
+	if got, want := found[0].SynonymsByFrequency(), []string{"car", "automobile", "auto"}; !slices.Equal(got, want) {
+		t.Errorf("SynonymsByFrequency() = %v; want %v", got, want)
+	}
+}
+
+func TestLoadDictionaryErrors(t *testing.T) {
+	for name, blob := range map[string]string{
+		"empty":           "",
+		"no magic":        "@ data.noun\n",
+		"unknown section": dictionaryMagic + "\n@ data.pronoun\n",
+		"no section":      dictionaryMagic + "\n00000001 06 n 01 car 0 000 | a car\n",
+		"bad synset":      dictionaryMagic + "\n@ data.noun\n00000001 06 n 01 car\n",
+	} {
+		if _, err := LoadDictionary(strings.NewReader(blob)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
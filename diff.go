@@ -0,0 +1,110 @@
+package wnram
+
+import "slices"
+
+// How a synset differs between two versions of the database
+type SynsetChange struct {
+	Old, New       Lookup
+	GlossChanged   bool
+	AddedMembers   []string // members of New only
+	RemovedMembers []string // members of Old only
+}
+
+// The differences between two databases for one part of speech, see Diff
+type DiffResult struct {
+	AddedSynsets   []Lookup // synsets of b without a counterpart in a
+	RemovedSynsets []Lookup // synsets of a without a counterpart in b
+	Changed        []SynsetChange
+	AddedWords     []string // lemmas of b that a doesn't have, sorted
+	RemovedWords   []string // lemmas of a that b doesn't have, sorted
+}
+
+// Compare the pos synsets of two loaded databases, typically two WordNet
+// versions, a being the older.  Offsets change between versions, so
+// synsets are matched by the sense keys of their members instead: each
+// synset of a is paired with the not yet paired synset of b sharing the
+// most sense keys with it.  Paired synsets whose gloss or membership
+// differ are reported as changed.  Synsets and lemmas are listed in data
+// file order and alphabetical order respectively.
+func Diff(a, b *Handle, pos PartOfSpeech) DiffResult {
+	var d DiffResult
+
+	bySenseKey := map[string]*cluster{}
+	for _, c := range b.db {
+		if c.pos == pos {
+			for i := range c.words {
+				bySenseKey[c.senseKey(i)] = c
+			}
+		}
+	}
+
+	paired := map[*cluster]bool{}
+	for _, old := range a.db {
+		if old.pos != pos {
+			continue
+		}
+		shared := map[*cluster]int{}
+		var best *cluster
+		for i := range old.words {
+			c, ok := bySenseKey[old.senseKey(i)]
+			if !ok || paired[c] {
+				continue
+			}
+			shared[c]++
+			if best == nil || shared[c] > shared[best] {
+				best = c
+			}
+		}
+		if best == nil {
+			d.RemovedSynsets = append(d.RemovedSynsets, Lookup{word: old.words[0].word, cluster: old})
+			continue
+		}
+		paired[best] = true
+
+		change := SynsetChange{
+			Old:            Lookup{word: old.words[0].word, cluster: old},
+			New:            Lookup{word: best.words[0].word, cluster: best},
+			GlossChanged:   old.gloss != best.gloss,
+			AddedMembers:   memberDifference(best, old),
+			RemovedMembers: memberDifference(old, best),
+		}
+		if change.GlossChanged || len(change.AddedMembers) > 0 || len(change.RemovedMembers) > 0 {
+			d.Changed = append(d.Changed, change)
+		}
+	}
+
+	for _, c := range b.db {
+		if c.pos == pos && !paired[c] {
+			d.AddedSynsets = append(d.AddedSynsets, Lookup{word: c.words[0].word, cluster: c})
+		}
+	}
+
+	d.AddedWords = lemmaDifference(b, a, pos)
+	d.RemovedWords = lemmaDifference(a, b, pos)
+	return d
+}
+
+// the members of x that aren't members of y
+func memberDifference(x, y *cluster) (words []string) {
+	for _, m := range x.words {
+		if !slices.ContainsFunc(y.words, func(o word) bool { return o.word == m.word }) {
+			words = append(words, m.word)
+		}
+	}
+	return words
+}
+
+// the pos lemmas of x that y doesn't have, sorted
+func lemmaDifference(x, y *Handle, pos PartOfSpeech) []string {
+	hasPOS := func(clusters []*cluster) bool {
+		return slices.ContainsFunc(clusters, func(c *cluster) bool { return c.pos == pos })
+	}
+	words := []string{}
+	for lemma, clusters := range x.index {
+		if hasPOS(clusters) && !hasPOS(y.index[lemma]) {
+			words = append(words, lemma)
+		}
+	}
+	slices.Sort(words)
+	return words
+}
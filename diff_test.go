@@ -0,0 +1,54 @@
+package wnram
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	older, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 05 n 02 dog 0 domestic_dog 0 000 | a domesticated canid\n" +
+			"00000002 06 n 01 widget 0 000 | a small gadget\n" +
+			"00000003 06 n 01 gramophone 0 000 | an old record player\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	// offsets have moved, dog gained a member and widget a new gloss
+	newer, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000010 06 n 01 widget 0 000 | a small mechanical device\n" +
+			"00000020 05 n 03 dog 0 domestic_dog 0 Canis_familiaris 0 000 | a domesticated canid\n" +
+			"00000030 06 n 01 smartphone 0 000 | a mobile phone\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	d := Diff(older, newer, Noun)
+	if len(d.RemovedSynsets) != 1 || d.RemovedSynsets[0].Lemma() != "gramophone" {
+		t.Errorf("unexpected removed synsets %v", d.RemovedSynsets)
+	}
+	if len(d.AddedSynsets) != 1 || d.AddedSynsets[0].Lemma() != "smartphone" {
+		t.Errorf("unexpected added synsets %v", d.AddedSynsets)
+	}
+	if len(d.Changed) != 2 {
+		t.Fatalf("expected two changed synsets, got %d", len(d.Changed))
+	}
+	dog, widget := d.Changed[0], d.Changed[1]
+	if dog.GlossChanged || !slices.Equal(dog.AddedMembers, []string{"Canis familiaris"}) || len(dog.RemovedMembers) != 0 {
+		t.Errorf("unexpected change to dog: %+v", dog)
+	}
+	if dog.Old.SynsetID() != "00000001-n" || dog.New.SynsetID() != "00000020-n" {
+		t.Errorf("dog paired as %s and %s", dog.Old.SynsetID(), dog.New.SynsetID())
+	}
+	if !widget.GlossChanged || len(widget.AddedMembers)+len(widget.RemovedMembers) != 0 {
+		t.Errorf("unexpected change to widget: %+v", widget)
+	}
+	if !slices.Equal(d.AddedWords, []string{"canis familiaris", "smartphone"}) || !slices.Equal(d.RemovedWords, []string{"gramophone"}) {
+		t.Errorf("added words %v, removed words %v", d.AddedWords, d.RemovedWords)
+	}
+
+	if same := Diff(older, older, Noun); len(same.Changed)+len(same.AddedSynsets)+len(same.RemovedSynsets)+len(same.AddedWords)+len(same.RemovedWords) != 0 {
+		t.Errorf("a database differs from itself: %+v", same)
+	}
+}
@@ -0,0 +1,77 @@
+package wnram
+
+import (
+	"slices"
+	"strings"
+)
+
+// the pointers from a synset (or one of its members) to its domain, and
+// the reverse pointers from a domain to its members
+const (
+	domainOf      = ContainsDomainTopic | ContainsDomainRegion | ContainsDomainUsage
+	domainMembers = InDomainTopic | InDomainRegion | InDomainUsage
+)
+
+// All topic, region and usage domains (e.g. "medicine", "United Kingdom",
+// "slang"), as the lemmas of their synsets, sorted and without
+// duplicates.
+func (h *Handle) Domains() []string {
+	names := []string{}
+	for d := range h.domains {
+		names = append(names, d.words[0].word)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// Every synset assigned to the given domain, e.g. "medicine", in data file
+// order.  domain is matched case insensitively against all members of
+// the domain synsets.  For words classified individually (e.g. as
+// British usage) the result is searched for as that word, otherwise as
+// its lemma.
+func (h *Handle) DomainMembers(domain string) []Lookup {
+	found := []Lookup{}
+	for _, d := range h.index[h.normalizeQuery(domain)] {
+		found = append(found, h.domains[d]...)
+	}
+	slices.SortStableFunc(found, func(a, b Lookup) int {
+		if a.cluster.pos != b.cluster.pos {
+			return int(a.cluster.pos) - int(b.cluster.pos)
+		}
+		return strings.Compare(a.cluster.debug, b.cluster.debug)
+	})
+	return slices.CompactFunc(found, func(a, b Lookup) bool {
+		return a.cluster == b.cluster && a.word == b.word
+	})
+}
+
+// indexes the members of every domain from the pointers on either side
+func buildDomainIndex(db []*cluster) map[*cluster][]Lookup {
+	domains := map[*cluster][]Lookup{}
+	add := func(domain, member *cluster, word string) {
+		if !slices.ContainsFunc(domains[domain], func(l Lookup) bool { return l.cluster == member && l.word == word }) {
+			domains[domain] = append(domains[domain], Lookup{word: word, cluster: member})
+		}
+	}
+	for _, c := range db {
+		for _, rel := range c.relations {
+			switch {
+			case rel.rel&domainOf != 0:
+				add(rel.target, c, c.words[0].word)
+			case rel.rel&domainMembers != 0:
+				add(c, rel.target, rel.target.words[0].word)
+			}
+		}
+		for _, m := range c.words {
+			for _, rel := range m.relations {
+				switch {
+				case rel.rel&domainOf != 0:
+					add(rel.target, c, m.word)
+				case rel.rel&domainMembers != 0:
+					add(c, rel.target, rel.target.words[rel.wordNumber].word)
+				}
+			}
+		}
+	}
+	return domains
+}
@@ -0,0 +1,49 @@
+package wnram
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDomains(t *testing.T) {
+	domains := wnInstance.Domains()
+	if !setContains(domains, []string{"medicine", "United Kingdom", "slang"}) {
+		t.Errorf("missing well known domains among %d", len(domains))
+	}
+	if !slices.IsSorted(domains) || len(slices.Compact(slices.Clone(domains))) != len(domains) {
+		t.Errorf("domains aren't sorted and unique")
+	}
+
+	var words []string
+	for _, l := range wnInstance.DomainMembers("Medicine") {
+		words = append(words, l.Word())
+	}
+	if !setContains(words, []string{"infusion", "urinalysis"}) {
+		t.Errorf("unexpected members of medicine: %v", words)
+	}
+	if got := wnInstance.DomainMembers("xyzzyplugh"); len(got) != 0 {
+		t.Errorf("expected no members of an unknown domain, got %d", len(got))
+	}
+
+	// domains are known from either side of the relation
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 01 surgery 0 001 -c 00000002 n 0000 | branch of medicine\n" +
+			"00000002 03 n 01 scalpel 0 000 | a thin knife\n" +
+			"00000003 03 n 01 suture 0 001 ;c 00000001 n 0000 | thread for stitching\n" +
+			"00000004 03 n 02 lift 0 elevator 0 001 ;r 00000005 n 0101 | a platform that rises\n" +
+			"00000005 03 n 01 Britain 0 000 | a country\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	if got := h.Domains(); !slices.Equal(got, []string{"Britain", "surgery"}) {
+		t.Errorf("Domains() = %v", got)
+	}
+	members := h.DomainMembers("surgery")
+	if len(members) != 2 || members[0].Lemma() != "scalpel" || members[1].Lemma() != "suture" {
+		t.Errorf("unexpected members of surgery: %v", members)
+	}
+	if british := h.DomainMembers("britain"); len(british) != 1 || british[0].Word() != "lift" {
+		t.Errorf("expected the word lift in Britain, got %v", british)
+	}
+}
@@ -0,0 +1,25 @@
+package wnram
+
+import "errors"
+
+// Returned (possibly wrapped) by methods that resolve a word to a single
+// answer when the word is not in the database.  Test with errors.Is.
+//
+// Lookup itself never returns this error: an unknown word yields an empty
+// result slice and a nil error, and a non-nil error from Lookup means the
+// criteria were invalid (or, for handles from NewIndexOnly,
+// ErrDataNotLoaded).
+var ErrWordNotFound = errors.New("word not found")
+
+// Returned by Lookup when the criteria contain no search string, or only
+// whitespace
+var ErrEmptyQuery = errors.New("empty string passed as criteria to lookup")
+
+// Returned by the methods that need synset data (Lookup, LookupByID, ...)
+// on a handle created with NewIndexOnly
+var ErrDataNotLoaded = errors.New("synset data not loaded")
+
+// Returned (wrapped) by LookupByID and LookupByOffset when no synset
+// starts at the offset, e.g. for an offset in the middle of a record or
+// one taken from another version of WordNet.  Test with errors.Is.
+var ErrSynsetNotFound = errors.New("synset not found")
@@ -0,0 +1,93 @@
+package wnram
+
+import (
+	"cmp"
+	"slices"
+)
+
+// A related word and how strongly it is related
+type WeightedWord struct {
+	Word   string
+	Weight float64
+}
+
+// How much each source of related words counts in ExpandQueryWith
+type ExpansionWeights struct {
+	Synonyms float64              // words sharing a synset with the query
+	Related  map[Relation]float64 // words of synsets related to the query's
+}
+
+// The weights used by ExpandQuery: 1 for synonyms, 0.8 for similar
+// adjectives, 0.5 for hypernyms and verb groups, and 0.4 for hyponyms and
+// derivationally related forms.  Antonyms are not included.
+func DefaultExpansionWeights() ExpansionWeights {
+	return ExpansionWeights{
+		Synonyms: 1,
+		Related: map[Relation]float64{
+			SimilarTo:                 0.8,
+			Hypernym:                  0.5,
+			VerbGroup:                 0.5,
+			Hyponym:                   0.4,
+			DerivationallyRelatedForm: 0.4,
+		},
+	}
+}
+
+// Words to expand a search for word (as pos) with, weighted by
+// DefaultExpansionWeights, strongest first.
+func (h *Handle) ExpandQuery(word string, pos PartOfSpeech) []WeightedWord {
+	return h.ExpandQueryWith(word, pos, DefaultExpansionWeights())
+}
+
+// Words to expand a search for word (as pos) with: its synonyms and the
+// words of related synsets across all its senses, each weighted by the
+// strongest source it was found through.  Sources with a weight of zero
+// or less aren't followed.  The word itself isn't included.  Results are
+// ordered by weight, strongest first, then alphabetically.
+func (h *Handle) ExpandQueryWith(word string, pos PartOfSpeech, weights ExpansionWeights) []WeightedWord {
+	found, err := h.Lookup(Criteria{Matching: word, POS: PartOfSpeechList{pos}})
+	if err != nil {
+		return nil
+	}
+
+	// the query and the base form it was found as
+	self := map[string]bool{h.normalizeQuery(word): true}
+	for _, f := range found {
+		if m := f.member(); m != nil {
+			self[normalize(m.word)] = true
+		}
+	}
+
+	best := map[string]float64{}
+	add := func(w string, weight float64) {
+		if !self[normalize(w)] && weight > best[w] {
+			best[w] = weight
+		}
+	}
+
+	for _, f := range found {
+		for _, w := range f.cluster.words {
+			add(w.word, weights.Synonyms)
+		}
+		for r, weight := range weights.Related {
+			if weight <= 0 {
+				continue
+			}
+			for _, w := range f.RelatedWords(r) {
+				add(w, weight)
+			}
+		}
+	}
+
+	expanded := make([]WeightedWord, 0, len(best))
+	for w, weight := range best {
+		expanded = append(expanded, WeightedWord{w, weight})
+	}
+	slices.SortFunc(expanded, func(a, b WeightedWord) int {
+		if c := cmp.Compare(b.Weight, a.Weight); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Word, b.Word)
+	})
+	return expanded
+}
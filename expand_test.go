@@ -0,0 +1,46 @@
+package wnram
+
+import "testing"
+
+func TestExpandQuery(t *testing.T) {
+	expanded := wnInstance.ExpandQuery("cars", Noun)
+	if len(expanded) == 0 {
+		t.Fatalf("no expansion for cars")
+	}
+
+	weights := map[string]float64{}
+	for i, w := range expanded {
+		if i > 0 && w.Weight > expanded[i-1].Weight {
+			t.Errorf("results not ordered by weight at %d: %v", i, expanded[i-1:i+1])
+		}
+		if _, dup := weights[w.Word]; dup {
+			t.Errorf("duplicate word %q", w.Word)
+		}
+		weights[w.Word] = w.Weight
+	}
+
+	if _, ok := weights["car"]; ok {
+		t.Errorf("the query itself shouldn't be included")
+	}
+	if weights["automobile"] != 1 {
+		t.Errorf("automobile should be a full weight synonym, got %v", weights["automobile"])
+	}
+	if weights["motor vehicle"] != 0.5 {
+		t.Errorf("motor vehicle should be a hypernym, got %v", weights["motor vehicle"])
+	}
+	if weights["cab"] != 0.4 {
+		t.Errorf("cab should be a hyponym, got %v", weights["cab"])
+	}
+
+	custom := wnInstance.ExpandQueryWith("car", Noun, ExpansionWeights{
+		Related: map[Relation]float64{Hypernym: 1},
+	})
+	for _, w := range custom {
+		if w.Word == "automobile" || w.Word == "cab" {
+			t.Errorf("disabled source included: %v", w)
+		}
+	}
+	if len(custom) == 0 {
+		t.Errorf("expected hypernyms with custom weights")
+	}
+}
@@ -0,0 +1,218 @@
+package wnram
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var tsvEscaper = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+
+// Write every synset of the given parts of speech (all if empty) to w as
+// tab separated values, one synset per row, preceded by a header row.  The
+// columns are synset_id, pos, lemmas (joined by "|") and gloss.  Tabs,
+// newlines and backslashes inside fields are escaped as \t, \n, \r, \\.
+func (h *Handle) ExportTSV(w io.Writer, pos PartOfSpeechList) error {
+	out := bufio.NewWriter(w)
+	if _, err := out.WriteString("synset_id\tpos\tlemmas\tgloss\n"); err != nil {
+		return err
+	}
+
+	err := h.Iterate(pos, func(l Lookup) error {
+		fields := []string{
+			l.SynsetID(),
+			l.POS().String(),
+			strings.Join(l.Synonyms(), "|"),
+			l.Gloss(),
+		}
+		for i := range fields {
+			fields[i] = tsvEscaper.Replace(fields[i])
+		}
+		_, err := out.WriteString(strings.Join(fields, "\t") + "\n")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return out.Flush()
+}
+
+// a synset as written by ExportJSON
+type synsetJSON struct {
+	ID               string                `json:"id"`
+	POS              string                `json:"pos"`
+	Lemmas           []string              `json:"lemmas"`
+	Gloss            string                `json:"gloss"`
+	Richness         int                   `json:"richness"`
+	Relations        map[string][]string   `json:"relations,omitempty"`
+	LexicalRelations []lexicalRelationJSON `json:"lexical_relations,omitempty"`
+}
+
+type lexicalRelationJSON struct {
+	Relation   string `json:"relation"`
+	Word       string `json:"word"`
+	Target     string `json:"target"`
+	TargetWord string `json:"target_word"`
+}
+
+// Write every synset of the given parts of speech (all if empty) to w as
+// JSON Lines, one object per synset holding its id, pos, lemmas, gloss,
+// SemanticRichness, the target ids of its semantic relations by relation
+// name, and its members' lexical relations, e.g.
+//
+//	{"id":"00000002-n","pos":"noun","lemmas":["widget"],"gloss":"a small gadget","richness":1,"relations":{"hypernym":["00000001-n"]}}
+//
+// Records are encoded as they are produced, so memory use doesn't grow
+// with the size of the export.
+func (h *Handle) ExportJSON(w io.Writer, pos PartOfSpeechList) error {
+	out := bufio.NewWriter(w)
+	enc := json.NewEncoder(out)
+
+	err := h.Iterate(pos, func(l Lookup) error {
+		rec := synsetJSON{
+			ID:       l.SynsetID(),
+			POS:      l.POS().String(),
+			Lemmas:   l.Synonyms(),
+			Gloss:    l.Gloss(),
+			Richness: l.SemanticRichness(),
+		}
+		for _, rel := range l.cluster.relations {
+			if rec.Relations == nil {
+				rec.Relations = map[string][]string{}
+			}
+			rec.Relations[rel.rel.name()] = append(rec.Relations[rel.rel.name()], rel.target.id())
+		}
+		for _, m := range l.cluster.words {
+			for _, rel := range m.relations {
+				rec.LexicalRelations = append(rec.LexicalRelations, lexicalRelationJSON{
+					Relation:   rel.rel.name(),
+					Word:       m.word,
+					Target:     rel.target.id(),
+					TargetWord: rel.target.words[rel.wordNumber].word,
+				})
+			}
+		}
+		return enc.Encode(rec)
+	})
+	if err != nil {
+		return err
+	}
+
+	return out.Flush()
+}
+
+// The graph of the relations in r between synsets as an adjacency map
+// from synset id to the ids of the synsets it points to, each once and in
+// pointer order, e.g. for loading into a graph database.  Lexical
+// relations count as edges between the synsets of their words.  Synsets
+// without such edges are left out.  This materializes the whole graph:
+// for all relations of WordNet 3.1 that is some 117,000 keys and 362,000
+// edges, around 25MB on top of the Handle.
+func (h *Handle) AdjacencyList(r Relation) map[string][]string {
+	adjacency := map[string][]string{}
+	for _, c := range h.db {
+		seen := map[*cluster]bool{}
+		var targets []string
+		add := func(target *cluster) {
+			if !seen[target] {
+				seen[target] = true
+				targets = append(targets, target.id())
+			}
+		}
+		for _, rel := range c.relations {
+			if rel.rel&r != 0 {
+				add(rel.target)
+			}
+		}
+		for _, m := range c.words {
+			for _, rel := range m.relations {
+				if rel.rel&r != 0 {
+					add(rel.target)
+				}
+			}
+		}
+		if len(targets) > 0 {
+			adjacency[c.id()] = targets
+		}
+	}
+	return adjacency
+}
+
+// the most synsets Neighborhood returns
+const maxNeighborhoodNodes = 250
+
+// the graph written by Neighborhood
+type neighborhoodJSON struct {
+	Nodes []nodeJSON `json:"nodes"`
+	Edges []edgeJSON `json:"edges"`
+}
+
+type nodeJSON struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	Gloss string `json:"gloss"`
+}
+
+type edgeJSON struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	Relation string `json:"relation"`
+}
+
+// The synsets within depth steps of l through the relations in r, as JSON
+// for a graph view: {"nodes": [{"id", "label", "gloss"}, ...], "edges":
+// [{"source", "target", "relation"}, ...]}.  Nodes are synsets in breadth
+// first order starting with l, labelled with their lemma, at most 250 of
+// them.  Edges are every relation in r between two of the nodes, once per
+// relation type and direction, lexical ones included.
+func (h *Handle) Neighborhood(l Lookup, r Relation, depth int) ([]byte, error) {
+	if depth < 0 {
+		return nil, fmt.Errorf("invalid depth %d", depth)
+	}
+	if l.cluster == nil {
+		return nil, fmt.Errorf("neighborhood of an empty lookup")
+	}
+
+	nodes := []*cluster{l.cluster}
+	if depth > 0 {
+		err := h.Walk(l, WalkOptions{Relations: r, MaxDepth: depth}, func(node Lookup, d int, _ Relation) bool {
+			if d > 0 && len(nodes) < maxNeighborhoodNodes {
+				nodes = append(nodes, node.cluster)
+			}
+			return len(nodes) < maxNeighborhoodNodes
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	included := map[*cluster]bool{}
+	for _, c := range nodes {
+		included[c] = true
+	}
+	graph := neighborhoodJSON{Nodes: []nodeJSON{}, Edges: []edgeJSON{}}
+	for _, c := range nodes {
+		graph.Nodes = append(graph.Nodes, nodeJSON{c.id(), c.words[0].word, c.gloss})
+
+		seen := map[edgeJSON]bool{}
+		add := func(rel Relation, target *cluster) {
+			e := edgeJSON{c.id(), target.id(), rel.name()}
+			if rel&r != 0 && included[target] && !seen[e] {
+				seen[e] = true
+				graph.Edges = append(graph.Edges, e)
+			}
+		}
+		for _, rel := range c.relations {
+			add(rel.rel, rel.target)
+		}
+		for _, m := range c.words {
+			for _, rel := range m.relations {
+				add(rel.rel, rel.target)
+			}
+		}
+	}
+	return json.Marshal(graph)
+}
@@ -0,0 +1,165 @@
+package wnram
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExportTSV(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 02 widget 0 gizmo_thing 0 000 | a small gadget; \"hand me\\the widget\"\n",
+		"data.verb": "00000001 29 v 01 tinker 0 000 | fiddle\twith something\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	var buf bytes.Buffer
+	if err := h.ExportTSV(&buf, nil); err != nil {
+		t.Fatalf("ExportTSV failed: %s", err)
+	}
+
+	expected := "synset_id\tpos\tlemmas\tgloss\n" +
+		"00000001-n\tnoun\twidget|gizmo thing\ta small gadget; \"hand me\\\\the widget\"\n" +
+		"00000001-v\tverb\ttinker\tfiddle\\twith something\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected TSV:\n%s\nwant:\n%s", buf.String(), expected)
+	}
+
+	buf.Reset()
+	if err := wnInstance.ExportTSV(&buf, PartOfSpeechList{Adverb}); err != nil {
+		t.Fatalf("ExportTSV failed: %s", err)
+	}
+	for i, row := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if cols := strings.Count(row, "\t"); cols != 3 {
+			t.Fatalf("row %d has %d tabs: %q", i, cols, row)
+		}
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 01 device 0 001 ~ 00000002 n 0000 | an instrumentality\n" +
+			"00000002 03 n 01 widget 0 001 @ 00000001 n 0000 | a small gadget\n",
+		"data.adj": "00000001 00 a 01 big 0 001 ! 00000002 a 0101 | large\n" +
+			"00000002 00 a 01 small 0 001 ! 00000001 a 0101 | little\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	var buf bytes.Buffer
+	if err := h.ExportJSON(&buf, PartOfSpeechList{Noun}); err != nil {
+		t.Fatalf("ExportJSON failed: %s", err)
+	}
+	expected := `{"id":"00000001-n","pos":"noun","lemmas":["device"],"gloss":"an instrumentality","richness":1,"relations":{"hyponym":["00000002-n"]}}` + "\n" +
+		`{"id":"00000002-n","pos":"noun","lemmas":["widget"],"gloss":"a small gadget","richness":1,"relations":{"hypernym":["00000001-n"]}}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected JSON:\n%s\nwant:\n%s", buf.String(), expected)
+	}
+
+	buf.Reset()
+	if err := h.ExportJSON(&buf, PartOfSpeechList{Adjective}); err != nil {
+		t.Fatalf("ExportJSON failed: %s", err)
+	}
+	if first, _, _ := strings.Cut(buf.String(), "\n"); first != `{"id":"00000001-a","pos":"adj","lemmas":["big"],"gloss":"large","richness":1,"lexical_relations":[{"relation":"antonym","word":"big","target":"00000002-a","target_word":"small"}]}` {
+		t.Errorf("unexpected record %s", first)
+	}
+
+	// every line of the full database decodes on its own
+	buf.Reset()
+	if err := wnInstance.ExportJSON(&buf, PartOfSpeechList{Adverb}); err != nil {
+		t.Fatalf("ExportJSON failed: %s", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for i, line := range lines {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("line %d doesn't decode: %s", i+1, err)
+		}
+	}
+	if len(lines) != 3625 {
+		t.Errorf("expected 3625 adverb records, got %d", len(lines))
+	}
+}
+
+func TestAdjacencyList(t *testing.T) {
+	adjacency := wnInstance.AdjacencyList(Hypernym | Antonym)
+
+	dog := specificSense(t, "dog", Noun, "domesticated")
+	canine := specificSense(t, "canine", Noun, "fissiped mammals")
+	if !slices.Contains(adjacency[dog.SynsetID()], canine.SynsetID()) {
+		t.Errorf("dog's hypernyms %v lack canine %s", adjacency[dog.SynsetID()], canine.SynsetID())
+	}
+
+	// antonymy is lexical, between the synsets of good and bad
+	good := specificSense(t, "good", Adjective, "desirable or positive")
+	bad := good.Related(Antonym)
+	if len(bad) == 0 || !slices.Contains(adjacency[good.SynsetID()], bad[0].SynsetID()) {
+		t.Errorf("good's antonyms missing from %v", adjacency[good.SynsetID()])
+	}
+
+	for id, targets := range adjacency {
+		if len(targets) == 0 || len(slices.Compact(slices.Sorted(slices.Values(targets)))) != len(targets) {
+			t.Fatalf("%s: empty or duplicate targets %v", id, targets)
+		}
+	}
+	if got := wnInstance.AdjacencyList(0); len(got) != 0 {
+		t.Errorf("expected no edges without relations, got %d", len(got))
+	}
+}
+
+func TestNeighborhood(t *testing.T) {
+	dog := specificSense(t, "dog", Noun, "domesticated")
+	canine := specificSense(t, "canine", Noun, "fissiped mammals")
+	data, err := wnInstance.Neighborhood(dog, Hypernym|Hyponym, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var graph neighborhoodJSON
+	if err := json.Unmarshal(data, &graph); err != nil {
+		t.Fatalf("invalid JSON: %s", err)
+	}
+	if len(graph.Nodes) < 2 || graph.Nodes[0] != (nodeJSON{dog.SynsetID(), "dog", dog.Gloss()}) {
+		t.Fatalf("unexpected nodes %v", graph.Nodes)
+	}
+	for _, e := range []edgeJSON{
+		{dog.SynsetID(), canine.SynsetID(), "hypernym"},
+		{canine.SynsetID(), dog.SynsetID(), "hyponym"},
+	} {
+		if !slices.Contains(graph.Edges, e) {
+			t.Errorf("missing edge %v", e)
+		}
+	}
+	ids := map[string]bool{}
+	for _, n := range graph.Nodes {
+		ids[n.ID] = true
+	}
+	for _, e := range graph.Edges {
+		if !ids[e.Source] || !ids[e.Target] {
+			t.Errorf("edge %v leaves the neighborhood", e)
+		}
+	}
+
+	entity := firstSense(t, wnInstance, "entity", Noun)
+	if data, err := wnInstance.Neighborhood(entity, Hyponym, 6); err != nil {
+		t.Error(err)
+	} else if json.Unmarshal(data, &graph); len(graph.Nodes) != maxNeighborhoodNodes {
+		t.Errorf("expected the node cap to apply, got %d nodes", len(graph.Nodes))
+	}
+
+	data, err = wnInstance.Neighborhood(dog, Hypernym, 0)
+	graph = neighborhoodJSON{}
+	if err != nil || json.Unmarshal(data, &graph) != nil || len(graph.Nodes) != 1 || !strings.Contains(string(data), `"edges":[]`) {
+		t.Errorf("unexpected depth 0 neighborhood %s (%v)", data, err)
+	}
+	if _, err := wnInstance.Neighborhood(dog, Hypernym, -1); err == nil {
+		t.Error("expected an error for a negative depth")
+	}
+	if _, err := wnInstance.Neighborhood(Lookup{}, Hypernym, 1); err == nil {
+		t.Error("expected an error for the zero Lookup")
+	}
+}
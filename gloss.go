@@ -0,0 +1,156 @@
+package wnram
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// the longest gloss excerpt DescribeSenses will show
+const maxDescriptionLength = 80
+
+// definition returns the part of a gloss before its example sentences,
+// which are quoted and separated from the definition by semicolons
+func definition(gloss string) string {
+	if i := strings.Index(gloss, `; "`); i >= 0 {
+		gloss = gloss[:i]
+	}
+	return strings.TrimSpace(gloss)
+}
+
+// truncate shortens s to at most n bytes, ending on a word boundary and
+// marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	cut := s[:n]
+	if i := strings.LastIndexByte(cut, ' '); i > 0 && s[n] != ' ' {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:") + "..."
+}
+
+// The gloss of this meaning followed by the definitions of the synsets it
+// points to as AlsoSee and, for adjective satellites, of their head
+// synset (SimilarTo), for a fuller dictionary entry when the gloss is
+// terse, e.g. for "yummy" "extremely pleasing to the sense of taste;
+// tasty: pleasing to the sense of taste".  Only those direct targets are
+// added, each once and without its examples; the satellites a head
+// synset is similar to are left out, there are often dozens.
+func (w *Lookup) ExpandedGloss() string {
+	r := AlsoSee
+	if w.IsSatellite() {
+		r |= SimilarTo
+	}
+
+	var b strings.Builder
+	b.WriteString(w.Gloss())
+	seen := map[*cluster]bool{w.synset(): true}
+	for _, r := range w.Related(r) {
+		if seen[r.cluster] {
+			continue
+		}
+		seen[r.cluster] = true
+		if b.Len() > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(r.Word() + ": " + definition(r.Gloss()))
+	}
+	return b.String()
+}
+
+// The first clause of the gloss of this meaning, up to the first
+// semicolon outside quotes, e.g. "extremely pleasing to the taste" for
+// ambrosial's "extremely pleasing to the taste; sweet and fragrant", for
+// compact labels.  Quoted example sentences before it are skipped; a
+// gloss of examples only gives the first, without its quotes.
+func (w *Lookup) ShortDefinition() string {
+	clauses := glossClauses(w.Gloss())
+	for _, c := range clauses {
+		if !strings.HasPrefix(c, `"`) {
+			return c
+		}
+	}
+	if len(clauses) > 0 {
+		return strings.Trim(clauses[0], `"`)
+	}
+	return ""
+}
+
+// glossClauses splits gloss at the semicolons outside quotes, trimming
+// the clauses and leaving out empty ones
+func glossClauses(gloss string) []string {
+	var clauses []string
+	start, quoted := 0, false
+	for i := 0; i <= len(gloss); i++ {
+		if i < len(gloss) && gloss[i] == '"' {
+			quoted = !quoted
+		}
+		if i == len(gloss) || (gloss[i] == ';' && !quoted) {
+			if c := strings.TrimSpace(gloss[start:i]); c != "" {
+				clauses = append(clauses, c)
+			}
+			start = i + 1
+		}
+	}
+	return clauses
+}
+
+// The example sentences of the gloss of this meaning, without their
+// quotes or the attributions some of them carry, e.g. "a heart big enough
+// to hold no grudges" and "a large heart" for the generous sense of big.
+func (w *Lookup) Examples() []string {
+	var examples []string
+	for _, c := range glossClauses(w.Gloss()) {
+		if !strings.HasPrefix(c, `"`) {
+			continue
+		}
+		c = c[1:]
+		if end := strings.LastIndexByte(c, '"'); end >= 0 {
+			c = c[:end]
+		}
+		if c = strings.TrimSpace(c); c != "" {
+			examples = append(examples, c)
+		}
+	}
+	return examples
+}
+
+// The Examples of this meaning that use word, to show the usage of the
+// exact synonym searched for: for the synset {big, large, magnanimous}
+// ExamplesFor("large") gives "a large and generous spirit" and "a large
+// heart".  Words are matched case insensitively where a word of the
+// example starts with them, so that inflected forms ("larger") count.
+// All examples if none of them use word.
+func (w *Lookup) ExamplesFor(word string) []string {
+	examples := w.Examples()
+	var using []string
+	for _, e := range examples {
+		if startsWord(normalize(e), normalize(word)) {
+			using = append(using, e)
+		}
+	}
+	if len(using) == 0 {
+		return examples
+	}
+	return using
+}
+
+// whether word occurs in text at the start of one of its words
+func startsWord(text, word string) bool {
+	if word == "" {
+		return false
+	}
+	for i := 0; ; {
+		j := strings.Index(text[i:], word)
+		if j < 0 {
+			return false
+		}
+		i += j
+		if r, _ := utf8.DecodeLastRuneInString(text[:i]); i == 0 || !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return true
+		}
+		i += len(word)
+	}
+}
@@ -0,0 +1,84 @@
+package wnram
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExpandedGloss(t *testing.T) {
+	yummy := firstSense(t, wnInstance, "yummy", Adjective)
+	if got, want := yummy.ExpandedGloss(), "extremely pleasing to the sense of taste; tasty: pleasing to the sense of taste"; got != want {
+		t.Errorf("ExpandedGloss() = %q; want %q", got, want)
+	}
+
+	tasty := firstSense(t, wnInstance, "tasty", Adjective)
+	got := tasty.ExpandedGloss()
+	if !strings.HasPrefix(got, tasty.Gloss()) || !strings.Contains(got, "; appetizing: appealing to") {
+		t.Errorf("see also definitions missing from %q", got)
+	}
+	if strings.Contains(got, "yummy") || strings.Contains(got, "ambrosial") {
+		t.Errorf("satellites of the head synset included in %q", got)
+	}
+
+	var zero Lookup
+	if got := zero.ExpandedGloss(); got != "" {
+		t.Errorf("expected no gloss for the zero Lookup, got %q", got)
+	}
+}
+
+func TestShortDefinition(t *testing.T) {
+	ambrosial := specificSense(t, "ambrosial", Adjective, "sweet and fragrant")
+	if got, want := ambrosial.ShortDefinition(), "extremely pleasing to the taste"; got != want {
+		t.Errorf("ShortDefinition() = %q; want %q", got, want)
+	}
+
+	for gloss, want := range map[string]string{
+		`a motor vehicle`:                          "a motor vehicle",
+		`pleasing; "a tasty; morsel"`:              "pleasing",
+		`"the dog barked; loudly"; barking animal`: "barking animal",
+		` "only an example" `:                      "only an example",
+		``:                                         "",
+	} {
+		l := Lookup{cluster: &cluster{gloss: gloss}}
+		if got := l.ShortDefinition(); got != want {
+			t.Errorf("ShortDefinition() of %q = %q; want %q", gloss, got, want)
+		}
+	}
+}
+
+func TestExamplesFor(t *testing.T) {
+	generous := specificSense(t, "big", Adjective, "generous and understanding")
+	for word, want := range map[string][]string{
+		"big":         {"a heart big enough to hold no grudges", "that's very big of you to be so forgiving"},
+		"Large":       {"a large and generous spirit", "a large heart"},
+		"magnanimous": {"magnanimous toward his enemies"},
+	} {
+		if got := generous.ExamplesFor(word); !slices.Equal(got, want) {
+			t.Errorf("ExamplesFor(%q) = %q; want %q", word, got, want)
+		}
+	}
+	if got := generous.Examples(); len(got) != 5 {
+		t.Errorf("expected 5 examples, got %q", got)
+	}
+
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.adj": "00000001 00 a 02 tasty 0 savory 0 000 | pleasing to the taste; \"tasty dishes\" - A. Cook; \"a morsel untasty to some\"\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	tasty := firstSense(t, h, "tasty", Adjective)
+	if got, want := tasty.ExamplesFor("tasty"), []string{"tasty dishes"}; !slices.Equal(got, want) {
+		t.Errorf("ExamplesFor(tasty) = %q; want %q", got, want)
+	}
+	// none mention savory
+	if got, want := tasty.ExamplesFor("savory"), []string{"tasty dishes", "a morsel untasty to some"}; !slices.Equal(got, want) {
+		t.Errorf("ExamplesFor(savory) = %q; want %q", got, want)
+	}
+
+	var zero Lookup
+	if got := zero.ExamplesFor("big"); len(got) != 0 {
+		t.Errorf("expected no examples for the zero Lookup, got %q", got)
+	}
+}
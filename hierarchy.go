@@ -0,0 +1,202 @@
+package wnram
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// relations followed when climbing from a synset towards the root
+const generalizations = Hypernym | InstanceHypernym
+
+// ancestors returns every synset reachable from c by following
+// hypernym pointers, along with the length of the shortest path to it.
+// c itself is included at distance zero.
+func ancestors(c *cluster) map[*cluster]int {
+	dist := map[*cluster]int{c: 0}
+	frontier := []*cluster{c}
+	for depth := 1; len(frontier) > 0; depth++ {
+		var next []*cluster
+		for _, f := range frontier {
+			for _, rel := range f.relations {
+				if rel.rel&generalizations == 0 {
+					continue
+				}
+				if _, seen := dist[rel.target]; !seen {
+					dist[rel.target] = depth
+					next = append(next, rel.target)
+				}
+			}
+		}
+		frontier = next
+	}
+	return dist
+}
+
+// Classify word under one of the candidate category words (e.g. "animal",
+// "vehicle", "food").  The categories are tried against the word's senses
+// in order, starting with the dominant (most frequent) one; the first
+// sense that has any category among its hypernyms decides, and the
+// category closest to it wins.  If no category is a hypernym of any
+// sense, the category closest to one by PathSimilarity wins (the earlier
+// sense and category on ties).  An error if no category can be compared
+// with any sense, as for adjectives.
+func (h *Handle) Categorize(word string, candidateCategories []string) (string, error) {
+	senses, err := h.sensesByFrequency(word, nil)
+	if err != nil {
+		return "", err
+	}
+	if len(senses) == 0 {
+		return "", fmt.Errorf("%w: %q", ErrWordNotFound, word)
+	}
+
+	categories := map[string][]Lookup{}
+	for _, name := range candidateCategories {
+		found, err := h.Lookup(Criteria{Matching: name})
+		if err != nil {
+			return "", err
+		}
+		categories[name] = found
+	}
+
+	for _, sense := range senses {
+		up := ancestors(sense.cluster)
+		best, bestDist := "", -1
+		for _, name := range candidateCategories {
+			for _, c := range categories[name] {
+				if d, ok := up[c.cluster]; ok && (bestDist < 0 || d < bestDist) {
+					best, bestDist = name, d
+				}
+			}
+		}
+		if bestDist >= 0 {
+			return best, nil
+		}
+	}
+
+	// no category is an ancestor, take the closest one
+	best, bestSim := "", 0.0
+	for _, sense := range senses {
+		for _, name := range candidateCategories {
+			for _, c := range categories[name] {
+				if sim, err := h.PathSimilarity(sense, c); err == nil && sim > bestSim {
+					best, bestSim = name, sim
+				}
+			}
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("none of %v can be compared with %q", candidateCategories, word)
+	}
+	return best, nil
+}
+
+// The synsets at the top of the hypernym hierarchy for pos, i.e. those
+// without any hypernym (for nouns this is just "entity", verbs have many
+// roots).  Adjectives and adverbs are not organized into a hypernym
+// hierarchy and have no roots.
+func (h *Handle) Roots(pos PartOfSpeech) (roots []Lookup) {
+	for _, c := range h.roots[pos] {
+		roots = append(roots, Lookup{
+			word:    c.words[0].word,
+			cluster: c,
+		})
+	}
+	return roots
+}
+
+// Coarse category tags for the dominant meaning of word: the lemmas of its
+// hypernyms, nearest first, e.g. "dog" -> ["canine", "domestic animal",
+// "carnivore", ...].  At most maxDepth levels are climbed and at most
+// maxTags tags returned; zero or less means no limit.
+func (h *Handle) SemanticTags(word string, pos PartOfSpeech, maxDepth, maxTags int) []string {
+	senses, err := h.sensesByFrequency(word, PartOfSpeechList{pos})
+	if err != nil || len(senses) == 0 {
+		return nil
+	}
+
+	up := ancestors(senses[0].cluster)
+	byDistance := make([]*cluster, 0, len(up))
+	for c, d := range up {
+		if d > 0 && (maxDepth <= 0 || d <= maxDepth) {
+			byDistance = append(byDistance, c)
+		}
+	}
+	slices.SortFunc(byDistance, func(a, b *cluster) int {
+		if up[a] != up[b] {
+			return up[a] - up[b]
+		}
+		return strings.Compare(a.id(), b.id())
+	})
+
+	var tags []string
+	for _, c := range byDistance {
+		if maxTags > 0 && len(tags) >= maxTags {
+			break
+		}
+		if tag := c.words[0].word; !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// The broadest meaning of word as pos, the sense with the fewest hypernym
+// links to the root of its hierarchy (see Depth), e.g. "an entity that
+// is not named specifically" for "thing", for assigning words to coarse
+// categories.  Ties go to the more frequent sense, so for adjectives and
+// adverbs, which have no hierarchy, this is the most frequent one, the
+// first Lookup result.
+func (h *Handle) MostGeneralSense(word string, pos PartOfSpeech) (Lookup, error) {
+	found, err := h.sensesByFrequency(word, PartOfSpeechList{pos})
+	if err != nil {
+		return Lookup{}, err
+	}
+	if len(found) == 0 {
+		return Lookup{}, fmt.Errorf("%w: %q (%s)", ErrWordNotFound, word, pos)
+	}
+	best, bestDepth := found[0], found[0].Depth()
+	for _, f := range found[1:] {
+		if d := f.Depth(); d < bestDepth {
+			best, bestDepth = f, d
+		}
+	}
+	return best, nil
+}
+
+// The named entities that are instances of word as pos, i.e. the targets
+// of the instance hyponym pointers of its senses, e.g. "physicist" ->
+// "Einstein", "Newton", ...  Only direct instances are returned; those of
+// more specific classes (the instances of "physicist" for "scientist")
+// can be collected with Walk.  Results are deduplicated and in sense
+// order.
+func (h *Handle) Instances(word string, pos PartOfSpeech) []Lookup {
+	found, err := h.Lookup(Criteria{Matching: word, POS: PartOfSpeechList{pos}})
+	if err != nil {
+		return nil
+	}
+	var instances []Lookup
+	seen := map[*cluster]bool{}
+	for _, f := range found {
+		for _, rel := range f.cluster.relations {
+			if rel.rel != InstanceHyponym || seen[rel.target] {
+				continue
+			}
+			seen[rel.target] = true
+			instances = append(instances, Lookup{
+				word:    rel.target.words[0].word,
+				cluster: rel.target,
+			})
+		}
+	}
+	return instances
+}
+
+// Whether this synset is a specific instance (a named entity such as a
+// person, place or event, e.g. "Einstein") rather than a class of things
+// (e.g. "physicist"), i.e. whether it has an instance hypernym.
+func (w *Lookup) IsInstance() bool {
+	return slices.ContainsFunc(w.synset().relations, func(rel semanticRelation) bool {
+		return rel.rel == InstanceHypernym
+	})
+}
@@ -0,0 +1,138 @@
+package wnram
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCategorize(t *testing.T) {
+	categories := []string{"animal", "vehicle", "food"}
+	tests := []struct {
+		word     string
+		expected []string
+	}{
+		{"salmon", []string{"animal", "food"}},
+		{"truck", []string{"vehicle"}},
+		{"spaghetti", []string{"food"}},
+		{"poodle", []string{"animal"}},
+	}
+
+	for _, tt := range tests {
+		got, err := wnInstance.Categorize(tt.word, categories)
+		if err != nil {
+			t.Errorf("Categorize(%q) failed: %s", tt.word, err)
+			continue
+		}
+		if !slices.Contains(tt.expected, got) {
+			t.Errorf("Categorize(%q) = %q; want one of %v", tt.word, got, tt.expected)
+		}
+	}
+
+	// no category is a hypernym of happiness: the closer one by path
+	// wins, 1/9 for food as "mental nourishment" against 1/12 for animal
+	if got, err := wnInstance.Categorize("happiness", []string{"animal", "food"}); err != nil || got != "food" {
+		t.Errorf("Categorize(happiness) = %q, %v; want food", got, err)
+	}
+	if _, err := wnInstance.Categorize("yummy", categories); err == nil {
+		t.Errorf("expected an error categorizing an adjective")
+	}
+	if _, err := wnInstance.Categorize("xyzzyplugh", categories); err == nil {
+		t.Errorf("expected an error categorizing an unknown word")
+	}
+}
+
+func TestRoots(t *testing.T) {
+	nouns := wnInstance.Roots(Noun)
+	if len(nouns) != 1 || nouns[0].Word() != "entity" {
+		t.Errorf("expected entity to be the only noun root, got %v", nouns)
+	}
+
+	verbs := wnInstance.Roots(Verb)
+	if len(verbs) < 100 {
+		t.Errorf("expected many verb roots, got %d", len(verbs))
+	}
+	for _, v := range verbs {
+		if len(v.Related(Hypernym)) != 0 {
+			t.Errorf("verb root %s has hypernyms", v.SynsetID())
+		}
+	}
+
+	if adj := wnInstance.Roots(Adjective); len(adj) != 0 {
+		t.Errorf("adjectives shouldn't have roots, got %d", len(adj))
+	}
+}
+
+func TestSemanticTags(t *testing.T) {
+	tags := wnInstance.SemanticTags("dog", Noun, 0, 0)
+	if !setContains(tags, []string{"canine", "animal", "organism", "physical entity", "entity"}) {
+		t.Errorf("missing tags for dog, got %v", tags)
+	}
+	if tags[len(tags)-1] != "entity" {
+		t.Errorf("expected the root to be the last tag, got %v", tags)
+	}
+
+	if got := wnInstance.SemanticTags("dog", Noun, 2, 0); len(got) == 0 || slices.Contains(got, "entity") {
+		t.Errorf("depth limit not honored, got %v", got)
+	}
+	if got := wnInstance.SemanticTags("dog", Noun, 0, 3); len(got) != 3 {
+		t.Errorf("expected 3 tags, got %v", got)
+	}
+	if got := wnInstance.SemanticTags("xyzzyplugh", Noun, 0, 0); got != nil {
+		t.Errorf("expected no tags for an unknown word, got %v", got)
+	}
+}
+
+func TestInstances(t *testing.T) {
+	instances := wnInstance.Instances("physicist", Noun)
+	var words []string
+	for _, i := range instances {
+		if !i.IsInstance() {
+			t.Errorf("%s is not an instance", i.Canonical())
+		}
+		words = append(words, i.Word())
+	}
+	if !setContains(words, []string{"Einstein", "Newton"}) {
+		t.Errorf("instances of physicist %v lack Einstein or Newton", words)
+	}
+
+	physicist := firstSense(t, wnInstance, "physicist", Noun)
+	if physicist.IsInstance() {
+		t.Error("physicist is a class, not an instance")
+	}
+	var zero Lookup
+	if zero.IsInstance() {
+		t.Error("the zero Lookup is not an instance")
+	}
+}
+
+func TestMostGeneralSense(t *testing.T) {
+	for word, want := range map[string]string{
+		"thing": "an entity that is not named specifically",
+		// of the two senses at depth 6 the first wins
+		"dog": "someone who is morally reprehensible",
+	} {
+		got, err := wnInstance.MostGeneralSense(word, Noun)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		if !strings.HasPrefix(got.Gloss(), want) {
+			t.Errorf("MostGeneralSense(%s) = %q; want %q", word, got.Gloss(), want)
+		}
+		found, _ := wnInstance.Lookup(Criteria{Matching: word, POS: PartOfSpeechList{Noun}})
+		for _, f := range found {
+			if f.Depth() < got.Depth() {
+				t.Errorf("%s (%d) is more general than %s (%d)", f.Gloss(), f.Depth(), got.Gloss(), got.Depth())
+			}
+		}
+	}
+
+	good, err := wnInstance.MostGeneralSense("good", Adjective)
+	if err != nil || !good.Equal(firstSense(t, wnInstance, "good", Adjective)) {
+		t.Errorf("expected the first sense of good, got %s (%v)", good.Gloss(), err)
+	}
+	if _, err := wnInstance.MostGeneralSense("xyzzyplugh", Noun); !errors.Is(err, ErrWordNotFound) {
+		t.Errorf("expected ErrWordNotFound, got %v", err)
+	}
+}
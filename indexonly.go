@@ -0,0 +1,112 @@
+package wnram
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Initialize a vocabulary only handle from the index.noun, index.verb,
+// index.adj and index.adv files and the exception files in the specified
+// directory, skipping the much larger data files.  It knows which lemmas
+// exist as which parts of speech and in how many synsets, so Contains,
+// ContainsAnyPOS, MorphWord and the other morphology methods and
+// SplitCompound work as usual.  Lookup and the other methods
+// returning meanings fail with ErrDataNotLoaded; methods without an error
+// result (SharedSynsets, Collocations, ...) return nil.
+func NewIndexOnly(dir string) (*Handle, error) {
+	l := newLoader(nil)
+	err := filepath.Walk(dir, func(filename string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		base := path.Base(filename)
+		if strings.HasPrefix(base, ".") || strings.HasPrefix(base, "data") || base == "index.sense" {
+			return nil
+		}
+		readLines := func(cb func([]byte, int64, int64) error) error {
+			return inPlaceReadLineFromPath(filename, cb)
+		}
+		if strings.HasPrefix(base, "index.") {
+			return l.loadIndex(filename, readLines)
+		}
+		return l.load(filename, readLines)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Handle{
+		index:      make(map[string][]*cluster),
+		byID:       map[string]*cluster{},
+		exceptions: l.exceptions,
+		excByPOS:   l.posExceptions,
+		surfaces:   reverseExceptions(l.posExceptions),
+		roots:      map[PartOfSpeech][]*cluster{},
+		indexOnly:  true,
+
+		taggedSenses: map[morphKey]int{},
+	}
+	for _, e := range l.indexEntries {
+		h.taggedSenses[morphKey{e.lemma, e.pos}] = e.tagged
+		for _, offset := range e.offsets {
+			// a stand in for the synset, without members or relations
+			k := ix{offset, e.pos}
+			c, ok := l.byOffset[k]
+			if !ok {
+				c = &cluster{pos: e.pos, debug: offset}
+				l.byOffset[k] = c
+			}
+			h.index[e.lemma] = append(h.index[e.lemma], c)
+		}
+	}
+	h.polysemy = countPolysemy(h.index)
+	return h, nil
+}
+
+// a line of an index.pos file
+type indexEntry struct {
+	lemma   string
+	pos     PartOfSpeech
+	offsets []string
+	tagged  int // tagsense_cnt, how many of the senses were tagged
+}
+
+// loadIndex reads an index file, whose lines have the form "lemma pos
+// synset_cnt p_cnt [ptr_symbol...] sense_cnt tagsense_cnt
+// synset_offset...".  The license header lines start with a space.
+func (l *loader) loadIndex(filename string, readLines func(cb func([]byte, int64, int64) error) error) error {
+	return readLines(func(data []byte, line, offset int64) error {
+		if len(data) == 0 || data[0] == ' ' {
+			return nil
+		}
+		fields := strings.Fields(string(data))
+		if len(fields) < 4 {
+			return fmt.Errorf("%s:%d: malformed index line", filename, line)
+		}
+		lp := lexable(fields[1])
+		pos, err := lp.lexPOS()
+		if err != nil {
+			return fmt.Errorf("%s:%d: %s", filename, line, err)
+		}
+		synsets, err1 := strconv.Atoi(fields[2])
+		pointers, err2 := strconv.Atoi(fields[3])
+		if err1 != nil || err2 != nil || synsets < 0 || pointers < 0 || len(fields) != 4+pointers+2+synsets {
+			return fmt.Errorf("%s:%d: malformed index line", filename, line)
+		}
+		tagged, err := strconv.Atoi(fields[4+pointers+1])
+		if err != nil {
+			return fmt.Errorf("%s:%d: malformed tagged sense count: %s", filename, line, err)
+		}
+		l.indexEntries = append(l.indexEntries, indexEntry{
+			lemma:   normalize(fields[0]),
+			pos:     pos,
+			offsets: fields[len(fields)-synsets:],
+			tagged:  tagged,
+		})
+		return nil
+	})
+}
@@ -0,0 +1,101 @@
+package wnram
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestNewIndexOnly(t *testing.T) {
+	dir := writeDataDir(t, map[string]string{
+		"index.noun": "  1 This software and database is being provided to you, the LICENSEE, by\n" +
+			"goose n 2 1 @ 2 1 01858313 07643734  \n" +
+			"ice_cream n 1 1 @ 1 1 07630414  \n",
+		"index.verb": "goose v 1 1 @ 1 0 01420695  \n",
+		"noun.exc":   "geese goose\n",
+		"data.noun":  "this file must not be read\n",
+	})
+	h, err := NewIndexOnly(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !h.Contains("goose", Noun) || !h.Contains("goose", Verb) || !h.Contains("ice cream", Noun) {
+		t.Error("index lemmas are missing")
+	}
+	if h.Contains("goose", Adjective) || h.ContainsAnyPOS("duck") {
+		t.Error("unexpected lemmas in index")
+	}
+	if !h.IsTagged("goose", Noun) || h.IsTagged("goose", Verb) {
+		t.Error("tagged sense counts not read from the index")
+	}
+	if got, want := h.WordInfo("goose", Noun), (WordInfo{Senses: 2, TaggedSenses: 1}); got != want {
+		t.Errorf("WordInfo(goose) = %+v; want %+v", got, want)
+	}
+	if got := h.MorphWord("geese", Noun); got != "goose" {
+		t.Errorf("MorphWord(geese) = %q, want goose", got)
+	}
+
+	if _, err := h.Lookup(Criteria{Matching: "goose"}); !errors.Is(err, ErrDataNotLoaded) {
+		t.Errorf("Lookup error = %v, want ErrDataNotLoaded", err)
+	}
+	if _, err := h.LookupByID("01858313-n"); !errors.Is(err, ErrDataNotLoaded) {
+		t.Errorf("LookupByID error = %v, want ErrDataNotLoaded", err)
+	}
+
+	if _, err := NewIndexOnly(writeDataDir(t, map[string]string{"index.adv": "badly r 2 1\n"})); err == nil {
+		t.Error("expected an error for a malformed index line")
+	}
+}
+
+func TestIndexOnlyFindsNoMeanings(t *testing.T) {
+	h, err := NewIndexOnly(writeDataDir(t, map[string]string{
+		"index.noun": "dog n 1 1 @ 1 1 02086723  \n" +
+			"hound n 2 1 @ 2 0 02086723 02090474  \n" +
+			"hot_dog n 1 1 @ 1 0 07713544  \n",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, call := range map[string]func() error{
+		"LookupPage":       func() error { _, _, err := h.LookupPage(Criteria{Matching: "dog"}); return err },
+		"LookupByOffset":   func() error { _, err := h.LookupByOffset(Noun, 2086723); return err },
+		"LookupSpecific":   func() error { _, err := h.LookupSpecific("dog", Noun, "canis"); return err },
+		"LookupSenseKey":   func() error { _, _, err := h.LookupSenseKey("dog%1:05:00::"); return err },
+		"LookupOrSuggest":  func() error { _, _, err := h.LookupOrSuggest("dog", Noun); return err },
+		"MostGeneralSense": func() error { _, err := h.MostGeneralSense("dog", Noun); return err },
+		"DominantPOS":      func() error { _, err := h.DominantPOS("dog"); return err },
+	} {
+		if err := call(); !errors.Is(err, ErrDataNotLoaded) {
+			t.Errorf("%s error = %v, want ErrDataNotLoaded", name, err)
+		}
+	}
+
+	for name, found := range map[string][]Lookup{
+		"SharedSynsets":           h.SharedSynsets("dog", "hound", Noun),
+		"Collocations":            h.Collocations("dog", Noun),
+		"SensesContaining":        h.SensesContaining("dog", Noun),
+		"GlossRegexp":             h.GlossRegexp(regexp.MustCompile("."), Noun),
+		"Instances":               h.Instances("dog", Noun),
+		"DomainMembers":           h.DomainMembers("dog"),
+		"Roots":                   h.Roots(Noun),
+		"SynsetsWithSynonymCount": h.SynsetsWithSynonymCount(Noun, 0, 10),
+	} {
+		if len(found) != 0 {
+			t.Errorf("%s found %d meanings on an index only handle", name, len(found))
+		}
+	}
+	if got := h.SensesByLexFile("dog", Noun); len(got) != 0 {
+		t.Errorf("SensesByLexFile found %v", got)
+	}
+	if got := h.Thesaurus("dog", Noun); len(got) != 0 {
+		t.Errorf("Thesaurus found %v", got)
+	}
+	if got := h.DescribeSenses("dog", Noun); len(got) != 0 {
+		t.Errorf("DescribeSenses found %v", got)
+	}
+	if got := h.Annotate([]Token{{Text: "dog"}}); got[0].Sense.cluster != nil {
+		t.Errorf("Annotate found %v", got[0].Sense)
+	}
+}
@@ -0,0 +1,33 @@
+package wnram
+
+import "encoding/json"
+
+type lookupJSON struct {
+	ID             string         `json:"id"`
+	Word           string         `json:"word"`
+	POS            string         `json:"pos"`
+	Synonyms       []string       `json:"synonyms"`
+	Gloss          string         `json:"gloss"`
+	Richness       int            `json:"richness"`
+	RelationCounts map[string]int `json:"relation_counts,omitempty"`
+}
+
+// Serialize a search result as a JSON object
+func (w Lookup) MarshalJSON() ([]byte, error) {
+	counts := map[string]int{}
+	for r := range relationNames {
+		if n := w.RelationCount(r); n > 0 {
+			counts[r.name()] = n
+		}
+	}
+
+	return json.Marshal(lookupJSON{
+		ID:             w.SynsetID(),
+		Word:           w.Word(),
+		POS:            w.POS().String(),
+		Synonyms:       w.Synonyms(),
+		Gloss:          w.Gloss(),
+		Richness:       w.SemanticRichness(),
+		RelationCounts: counts,
+	})
+}
@@ -0,0 +1,42 @@
+package wnram
+
+import "strings"
+
+// the names of the lexicographer files, by number
+var lexFileNames = strings.Fields(`adj.all adj.pert adv.all noun.Tops
+	noun.act noun.animal noun.artifact noun.attribute noun.body
+	noun.cognition noun.communication noun.event noun.feeling noun.food
+	noun.group noun.location noun.motive noun.object noun.person
+	noun.phenomenon noun.plant noun.possession noun.process noun.quantity
+	noun.relation noun.shape noun.state noun.substance noun.time verb.body
+	verb.change verb.cognition verb.communication verb.competition
+	verb.consumption verb.contact verb.creation verb.emotion verb.motion
+	verb.perception verb.possession verb.social verb.stative verb.weather
+	adj.ppl`)
+
+// The name of the lexicographer file this synset comes from, a coarse
+// semantic category such as "noun.animal" or "verb.motion".  Empty for the
+// zero Lookup and for file numbers WordNet doesn't define.
+func (w *Lookup) LexFile() string {
+	c := w.synset()
+	if c == noSynset || c.lexFile < 0 || c.lexFile >= len(lexFileNames) {
+		return ""
+	}
+	return lexFileNames[c.lexFile]
+}
+
+// The senses of word as pos grouped by the name of their lexicographer
+// file (see LexFile), showing how widely its meanings spread, e.g.
+// noun.animal, noun.food and noun.attribute for "bass".  Each group is in
+// Lookup order.
+func (h *Handle) SensesByLexFile(word string, pos PartOfSpeech) map[string][]Lookup {
+	found, err := h.Lookup(Criteria{Matching: word, POS: PartOfSpeechList{pos}})
+	if err != nil {
+		return nil
+	}
+	groups := map[string][]Lookup{}
+	for _, f := range found {
+		groups[f.LexFile()] = append(groups[f.LexFile()], f)
+	}
+	return groups
+}
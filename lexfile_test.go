@@ -0,0 +1,50 @@
+package wnram
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLexFile(t *testing.T) {
+	dog := specificSense(t, "dog", Noun, "domesticated")
+	if got := dog.LexFile(); got != "noun.animal" {
+		t.Errorf("LexFile() of dog = %q; want noun.animal", got)
+	}
+	run := firstSense(t, wnInstance, "run", Verb)
+	if got := run.LexFile(); !strings.HasPrefix(got, "verb.") {
+		t.Errorf("LexFile() of run = %q; want a verb file", got)
+	}
+	var zero Lookup
+	if got := zero.LexFile(); got != "" {
+		t.Errorf("LexFile() of the zero Lookup = %q", got)
+	}
+}
+
+func TestSensesByLexFile(t *testing.T) {
+	groups := wnInstance.SensesByLexFile("bass", Noun)
+	for _, name := range []string{"noun.animal", "noun.food", "noun.communication"} {
+		if len(groups[name]) == 0 {
+			t.Errorf("no %s senses of bass in %v", name, groups)
+		}
+	}
+
+	all, _ := wnInstance.Lookup(Criteria{Matching: "bass", POS: PartOfSpeechList{Noun}})
+	total := 0
+	for name, senses := range groups {
+		total += len(senses)
+		for i, s := range senses {
+			if s.LexFile() != name {
+				t.Errorf("%s in the %s group", s.Canonical(), name)
+			}
+			if i > 0 && s.SynsetID() <= senses[i-1].SynsetID() {
+				t.Errorf("%s group out of Lookup order", name)
+			}
+		}
+	}
+	if total != len(all) {
+		t.Errorf("got %d grouped senses, want %d", total, len(all))
+	}
+	if got := wnInstance.SensesByLexFile("xyzzyplugh", Noun); len(got) != 0 {
+		t.Errorf("expected no groups for an unknown word, got %v", got)
+	}
+}
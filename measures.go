@@ -0,0 +1,189 @@
+package wnram
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// A way of scoring how similar two synsets are, higher meaning more
+// similar.  Implement it to use a custom measure with Handle.Similarity.
+type SimilarityMeasure interface {
+	Score(h *Handle, a, b Lookup) (float64, error)
+}
+
+// The built in measures.  All of them compare two nouns or two verbs and
+// follow Options.VirtualVerbRoot for verbs without a common hypernym.
+var (
+	// See Handle.PathSimilarity
+	Path SimilarityMeasure = pathMeasure{}
+	// See Handle.WuPalmerSimilarity
+	WuPalmer SimilarityMeasure = wuPalmerMeasure{}
+	// -log(p / 2d), where p is the number of nodes on the shortest path
+	// between the synsets and d the depth of the deepest synset of their
+	// part of speech, counted in nodes
+	LeacockChodorow SimilarityMeasure = leacockChodorowMeasure{}
+	// The information content of the most informative common hypernym,
+	// see Handle.InformationContent
+	Resnik SimilarityMeasure = resnikMeasure{}
+	// 2*IC(lcs) / (IC(a) + IC(b)), in [0, 1]
+	Lin SimilarityMeasure = linMeasure{}
+	// 1 / (IC(a) + IC(b) - 2*IC(lcs)), +Inf for synsets at distance zero
+	JiangConrath SimilarityMeasure = jiangConrathMeasure{}
+)
+
+// The similarity of a and b according to m, e.g. h.Similarity(a, b, Lin)
+func (h *Handle) Similarity(a, b Lookup, m SimilarityMeasure) (float64, error) {
+	if m == nil {
+		return 0, fmt.Errorf("no similarity measure")
+	}
+	return m.Score(h, a, b)
+}
+
+type pathMeasure struct{}
+
+func (pathMeasure) Score(h *Handle, a, b Lookup) (float64, error) {
+	return h.PathSimilarity(a, b)
+}
+
+type wuPalmerMeasure struct{}
+
+func (wuPalmerMeasure) Score(h *Handle, a, b Lookup) (float64, error) {
+	return h.WuPalmerSimilarity(a, b)
+}
+
+type leacockChodorowMeasure struct{}
+
+func (leacockChodorowMeasure) Score(h *Handle, a, b Lookup) (float64, error) {
+	// the path similarity is one over the nodes on the shortest path
+	path, err := h.PathSimilarity(a, b)
+	if err != nil {
+		return 0, err
+	}
+	h.derived.ensureDepths()
+	d := h.derived.maxDepths[a.cluster.pos] + 1
+	if a.cluster.pos == Verb && h.opts.VirtualVerbRoot {
+		d++
+	}
+	return math.Log(2 * float64(d) * path), nil
+}
+
+// the information content of a and b and of their most informative
+// common hypernym
+func (h *Handle) lcsIC(a, b Lookup) (icA, icB, icLCS float64, err error) {
+	if err := checkComparable(a, b); err != nil {
+		return 0, 0, 0, err
+	}
+	h.ensureIC()
+
+	upB := ancestors(b.cluster)
+	found := false
+	for c := range ancestors(a.cluster) {
+		if _, ok := upB[c]; ok {
+			if ic := h.derived.ic[c]; !found || ic > icLCS {
+				icLCS = ic
+			}
+			found = true
+		}
+	}
+	if !found && (a.cluster.pos != Verb || !h.opts.VirtualVerbRoot) {
+		return 0, 0, 0, fmt.Errorf("%s and %s share no hypernym", a.cluster.id(), b.cluster.id())
+	}
+	// otherwise the virtual root, which carries no information
+
+	return h.derived.ic[a.cluster], h.derived.ic[b.cluster], icLCS, nil
+}
+
+type resnikMeasure struct{}
+
+func (resnikMeasure) Score(h *Handle, a, b Lookup) (float64, error) {
+	_, _, ic, err := h.lcsIC(a, b)
+	return ic, err
+}
+
+type linMeasure struct{}
+
+func (linMeasure) Score(h *Handle, a, b Lookup) (float64, error) {
+	icA, icB, ic, err := h.lcsIC(a, b)
+	if err != nil {
+		return 0, err
+	}
+	if icA+icB == 0 {
+		// both are roots carrying no information
+		return 1, nil
+	}
+	return 2 * ic / (icA + icB), nil
+}
+
+type jiangConrathMeasure struct{}
+
+func (jiangConrathMeasure) Score(h *Handle, a, b Lookup) (float64, error) {
+	icA, icB, ic, err := h.lcsIC(a, b)
+	if err != nil {
+		return 0, err
+	}
+	if distance := icA + icB - 2*ic; distance > 0 {
+		return 1 / distance, nil
+	}
+	return math.Inf(1), nil
+}
+
+// The similarities of every pair of words as pos, according to m: entry
+// [i][j] is the best score of any sense of words[i] with any sense of
+// words[j], zero if no pair of their senses can be compared (e.g. verbs
+// without a common hypernym).  The matrix is symmetric, its diagonal
+// holds each word's score with itself.  The senses of each word are
+// looked up once and the rows are computed on GOMAXPROCS goroutines, so
+// custom measures must be safe for concurrent use.  Unknown words are an
+// error.
+func (h *Handle) SimilarityMatrix(words []string, pos PartOfSpeech, m SimilarityMeasure) ([][]float64, error) {
+	if m == nil {
+		return nil, fmt.Errorf("no similarity measure")
+	}
+	senses := make([][]Lookup, len(words))
+	for i, w := range words {
+		found, err := h.Lookup(Criteria{Matching: w, POS: PartOfSpeechList{pos}})
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			return nil, fmt.Errorf("%w: %q", ErrWordNotFound, w)
+		}
+		senses[i] = found
+	}
+
+	matrix := make([][]float64, len(words))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(words))
+	}
+	rows := make(chan int)
+	var wg sync.WaitGroup
+	for range runtime.GOMAXPROCS(0) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rows {
+				// each worker fills the upper triangle of its row and
+				// mirrors it, no two write the same entry
+				for j := i; j < len(words); j++ {
+					best, ok := 0.0, false
+					for _, a := range senses[i] {
+						for _, b := range senses[j] {
+							if s, err := m.Score(h, a, b); err == nil && (!ok || s > best) {
+								best, ok = s, true
+							}
+						}
+					}
+					matrix[i][j], matrix[j][i] = best, best
+				}
+			}
+		}()
+	}
+	for i := range words {
+		rows <- i
+	}
+	close(rows)
+	wg.Wait()
+	return matrix, nil
+}
@@ -0,0 +1,119 @@
+package wnram
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSimilarityMeasures(t *testing.T) {
+	dog := specificSense(t, "dog", Noun, "domesticated")
+	cat := specificSense(t, "cat", Noun, "feline mammal")
+	car := specificSense(t, "car", Noun, "motor vehicle")
+	run := firstSense(t, wnInstance, "run", Verb)
+
+	for name, m := range map[string]SimilarityMeasure{
+		"path": Path, "wu-palmer": WuPalmer, "leacock-chodorow": LeacockChodorow,
+		"resnik": Resnik, "lin": Lin, "jiang-conrath": JiangConrath,
+	} {
+		dogCat, err := wnInstance.Similarity(dog, cat, m)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		dogCar, _ := wnInstance.Similarity(dog, car, m)
+		if dogCat <= dogCar || dogCar < 0 {
+			t.Errorf("%s: expected dog to be closer to cat (%v) than to car (%v)", name, dogCat, dogCar)
+		}
+		if catDog, _ := wnInstance.Similarity(cat, dog, m); catDog != dogCat {
+			t.Errorf("%s isn't symmetric: %v != %v", name, catDog, dogCat)
+		}
+		if same, _ := wnInstance.Similarity(dog, dog, m); same < dogCat {
+			t.Errorf("%s: dog is less similar to itself (%v) than to cat (%v)", name, same, dogCat)
+		}
+		if _, err := wnInstance.Similarity(dog, run, m); err == nil {
+			t.Errorf("%s: expected an error comparing a noun with a verb", name)
+		}
+	}
+
+	if lin, _ := wnInstance.Similarity(dog, dog, Lin); lin != 1 {
+		t.Errorf("Lin similarity of dog with itself = %v", lin)
+	}
+	if jc, _ := wnInstance.Similarity(dog, dog, JiangConrath); !math.IsInf(jc, 1) {
+		t.Errorf("Jiang-Conrath similarity of dog with itself = %v", jc)
+	}
+	ic, _ := wnInstance.InformationContent(dog)
+	if resnik, _ := wnInstance.Similarity(dog, dog, Resnik); resnik != ic {
+		t.Errorf("Resnik similarity of dog with itself = %v, want its IC %v", resnik, ic)
+	}
+	// the most informative common hypernym is the root, not -0
+	entity := wnInstance.Roots(Noun)[0]
+	if resnik, err := wnInstance.Similarity(dog, entity, Resnik); err != nil || resnik != 0 || math.Signbit(resnik) {
+		t.Errorf("Resnik similarity of dog with entity = %v, %v; want 0", resnik, err)
+	}
+
+	if _, err := wnInstance.Similarity(dog, cat, nil); err == nil {
+		t.Errorf("expected an error without a measure")
+	}
+	if score, err := wnInstance.Similarity(dog, cat, sameLemma{}); err != nil || score != 0 {
+		t.Errorf("custom measure gave %v, %v", score, err)
+	}
+}
+
+// a custom measure
+type sameLemma struct{}
+
+func (sameLemma) Score(h *Handle, a, b Lookup) (float64, error) {
+	if a.Lemma() == b.Lemma() {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func TestSimilarityMatrix(t *testing.T) {
+	words := []string{"dog", "cat", "car", "dogs"}
+	matrix, err := wnInstance.SimilarityMatrix(words, Noun, Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matrix) != len(words) {
+		t.Fatalf("expected a %dx%[1]d matrix, got %d rows", len(words), len(matrix))
+	}
+	for i := range matrix {
+		if matrix[i][i] != 1 {
+			t.Errorf("similarity of %s with itself = %f", words[i], matrix[i][i])
+		}
+		for j := range matrix[i] {
+			if matrix[i][j] != matrix[j][i] {
+				t.Errorf("matrix not symmetric at %d, %d", i, j)
+			}
+		}
+	}
+	if matrix[0][1] <= matrix[0][2] {
+		t.Errorf("dog should be more similar to cat (%f) than to car (%f)", matrix[0][1], matrix[0][2])
+	}
+	if matrix[0][3] != 1 {
+		t.Errorf("dog and dogs share their senses, got %f", matrix[0][3])
+	}
+
+	// the best pair of senses
+	best := 0.0
+	dogs, _ := wnInstance.Lookup(Criteria{Matching: "dog", POS: PartOfSpeechList{Noun}})
+	cats, _ := wnInstance.Lookup(Criteria{Matching: "cat", POS: PartOfSpeechList{Noun}})
+	for _, a := range dogs {
+		for _, b := range cats {
+			if s, err := wnInstance.Similarity(a, b, Path); err == nil && s > best {
+				best = s
+			}
+		}
+	}
+	if matrix[0][1] != best {
+		t.Errorf("dog/cat similarity %f, want the best sense pair's %f", matrix[0][1], best)
+	}
+
+	if _, err := wnInstance.SimilarityMatrix([]string{"dog", "xyzzyplugh"}, Noun, Path); !errors.Is(err, ErrWordNotFound) {
+		t.Errorf("expected ErrWordNotFound for an unknown word, got %v", err)
+	}
+	if _, err := wnInstance.SimilarityMatrix(words, Noun, nil); err == nil {
+		t.Error("expected an error without a measure")
+	}
+}
@@ -0,0 +1,544 @@
+package wnram
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// All the base forms morphology finds for an inflected word
+type MorphResult struct {
+	// The preferred base form: what MorphWord returns whenever that is a
+	// lemma of the requested part of speech, otherwise the first base form
+	// found in the exception lists or by the rules.  Empty if word has no
+	// base form other than itself.
+	Base string
+	// Other possible base forms, e.g. "axe" and "axis" when Base is "ax"
+	// for "axes"
+	Alternatives []string
+	// Whether there is more than one possible base form
+	Ambiguous bool
+}
+
+// Like MorphWord, but reports every base form of word as pos found in the
+// user and wordnet exception lists or by the suffix rules, rather than
+// just the first.  Apart from user exceptions, only base forms that are
+// pos lemmas are reported.
+func (h *Handle) MorphDetailed(word string, pos PartOfSpeech) MorphResult {
+	word = normalize(word)
+
+	var bases []string
+	add := func(base string) {
+		if base != "" && base != word && !slices.Contains(bases, base) {
+			bases = append(bases, base)
+		}
+	}
+
+	h.mu.RLock()
+	if base, ok := h.userException(word, pos); ok {
+		add(base)
+	}
+	rules := h.userSuffixes[pos]
+	h.mu.RUnlock()
+
+	if base := h.MorphWord(word, pos); h.Contains(base, pos) {
+		add(base)
+	}
+	if !h.opts.DisableMorphExceptions {
+		for _, base := range h.excByPOS[pos][word] {
+			if h.Contains(base, pos) {
+				add(base)
+			}
+		}
+	}
+	for _, rule := range rules {
+		if base, ok := rule.apply(word); ok && h.Contains(base, pos) {
+			add(base)
+		}
+	}
+	if rulesApply(word, pos) {
+		for i := range counts[int(pos)] {
+			if base := wordbase(word, offsets[int(pos)]+i); h.Contains(base, pos) {
+				add(base)
+			}
+		}
+	}
+
+	if len(bases) == 0 {
+		return MorphResult{}
+	}
+	return MorphResult{
+		Base:         bases[0],
+		Alternatives: bases[1:],
+		Ambiguous:    len(bases) > 1,
+	}
+}
+
+// whether the suffix rules may be applied to word as pos
+func rulesApply(word string, pos PartOfSpeech) bool {
+	switch pos {
+	case Adverb:
+		return false
+	case Noun:
+		return !strings.HasSuffix(word, "ss") && len(word) > 2
+	}
+	// e.g. NoPOS, which has no rules
+	return int(pos) < len(offsets)
+}
+
+// MorphWord for each of tokens, or the token itself where it has no
+// other base form, e.g. ["the", "geese", "flew"] gives ["the", "goose",
+// "flew"] as nouns.  The result has one lemma per token, in order.
+// Cheaper than calling MorphWord per token for whole documents: repeated
+// tokens are reduced once per call, and through the morphology cache
+// (Options.MorphCacheSize) across calls.
+func (h *Handle) LemmatizeTokens(tokens []string, pos PartOfSpeech) []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	lemmas := make([]string, len(tokens))
+	seen := map[string]string{}
+	for i, t := range tokens {
+		base, ok := seen[t]
+		if !ok {
+			base = h.cachedMorphWord(t, pos)
+			seen[t] = base
+		}
+		if base == "" {
+			base = t
+		}
+		lemmas[i] = base
+	}
+	return lemmas
+}
+
+// MorphWord for text in its original casing: word is lemmatized case
+// insensitively and the base form is returned cased like word, e.g.
+// "Dog" for "Dogs", "GEESE" gives "GOOSE" and "Fire Men" gives "Fire
+// Man".  Words in mixed case ("iPhones") give a lower case base.  Empty
+// if word has no base form other than itself.
+func (h *Handle) MorphPreserveCase(word string, pos PartOfSpeech) string {
+	base := h.MorphWord(h.normalizeQuery(word), pos)
+	if base == "" {
+		return ""
+	}
+
+	switch casing(word) {
+	case upperCase:
+		return strings.ToUpper(base)
+	case titleCase:
+		words := strings.Split(base, " ")
+		for i, w := range words {
+			words[i] = capitalize(w)
+		}
+		return strings.Join(words, " ")
+	case capitalized:
+		return capitalize(base)
+	}
+	return base
+}
+
+type casingPattern int
+
+const (
+	lowerCase   casingPattern = iota
+	upperCase                 // "DOGS", at least two letters
+	titleCase                 // "Fire Men", every word capitalized
+	capitalized               // "Fire men", the first letter only
+)
+
+// the casing pattern of s, lowerCase for mixed casings
+func casing(s string) casingPattern {
+	var letters, upper int
+	title := true
+	for _, w := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == '_' }) {
+		for i, r := range []rune(w) {
+			if !unicode.IsLetter(r) {
+				continue
+			}
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+			if (i == 0) != unicode.IsUpper(r) {
+				title = false
+			}
+		}
+	}
+
+	first, _ := utf8.DecodeRuneInString(strings.TrimSpace(s))
+	switch {
+	case upper == 0:
+		return lowerCase
+	case upper == letters && letters > 1:
+		return upperCase
+	case title:
+		return titleCase
+	case upper == 1 && unicode.IsUpper(first):
+		return capitalized
+	}
+	return lowerCase
+}
+
+// s with its first letter upper cased
+func capitalize(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[n:]
+}
+
+// reverseExceptions maps every base form in exc to the inflected forms
+// that reduce to it
+func reverseExceptions(exc map[PartOfSpeech]map[string][]string) map[PartOfSpeech]map[string][]string {
+	rev := map[PartOfSpeech]map[string][]string{}
+	for pos, forms := range exc {
+		rev[pos] = map[string][]string{}
+		for surface, bases := range forms {
+			for _, base := range bases {
+				rev[pos][base] = append(rev[pos][base], surface)
+			}
+		}
+	}
+	return rev
+}
+
+// All the inflected forms of lemma as pos, e.g. "is", "was", "were",
+// "been", "being", "am" and "are" for the verb "be", sorted.  Irregular
+// forms come from the exception lists (and AddException) and are
+// combined with the regular English inflections for everything they don't
+// cover: plurals for nouns, third person, past and present participle
+// forms for verbs (inflecting the first word of phrasal verbs),
+// comparatives and superlatives for short single word adjectives.  So
+// "go" gives "goes", "going", "gone" and "went", and "child" only
+// "children".  Regular forms are only generated for parts of speech whose
+// exception list (noun.exc, verb.exc, adj.exc) is loaded, as without it
+// an irregular word would get made up forms such as "goed" or "gooder",
+// and forms whose spelling the rules can't settle are left out: the past
+// and present participle of verbs of several syllables ending in a
+// vowel and a consonant ("visited" but "admitted") and the comparatives
+// of adjectives of more than one syllable other than those in -y.
+// Adverbs have irregular forms only.
+func (h *Handle) SurfaceForms(lemma string, pos PartOfSpeech) []string {
+	lemma = normalize(lemma)
+	if lemma == "" {
+		return nil
+	}
+
+	forms := slices.Clone(h.surfaces[pos][lemma])
+	h.mu.RLock()
+	for surface, base := range h.userExceptions[pos] {
+		if base == lemma {
+			forms = append(forms, surface)
+		}
+	}
+	h.mu.RUnlock()
+
+	if len(h.excByPOS[pos]) > 0 {
+		covered := map[inflection]bool{}
+		for _, f := range forms {
+			covered[inflectionOf(f, pos)] = true
+		}
+		for infl, form := range h.regularForms(lemma, pos) {
+			if !covered[infl] {
+				forms = append(forms, form)
+			}
+		}
+	}
+
+	slices.Sort(forms)
+	return slices.Compact(forms)
+}
+
+// An inflection a surface form of a lemma is
+type inflection int
+
+const (
+	plural inflection = iota
+	thirdPerson
+	past // the past tense and the past participle
+	presentParticiple
+	comparative
+	superlative
+)
+
+// the inflection an irregular form of a pos lemma most likely is, from
+// its ending: e.g. "has" is third person and "went" past
+func inflectionOf(form string, pos PartOfSpeech) inflection {
+	switch pos {
+	case Verb:
+		head, _, _ := strings.Cut(form, " ")
+		switch {
+		case strings.HasSuffix(head, "ing"):
+			return presentParticiple
+		case strings.HasSuffix(head, "s"):
+			return thirdPerson
+		}
+		return past
+	case Adjective:
+		if strings.HasSuffix(form, "st") {
+			return superlative
+		}
+		return comparative
+	}
+	return plural
+}
+
+// regularForms inflects lemma following the regular English spelling
+// rules, leaving out the forms they can't be sure of
+func (h *Handle) regularForms(lemma string, pos PartOfSpeech) map[inflection]string {
+	words := strings.Split(lemma, " ")
+	switch pos {
+	case Noun:
+		// the head of a compound noun is its last word
+		last := len(words) - 1
+		words[last] = h.pluralize(words[last])
+		return map[inflection]string{plural: strings.Join(words, " ")}
+	case Verb:
+		head, rest := words[0], strings.Join(words[1:], " ")
+		if rest != "" {
+			rest = " " + rest
+		}
+		forms := map[inflection]string{thirdPerson: addS(head) + rest}
+		if pastStem, ingStem, ok := verbStems(head); ok {
+			forms[past] = pastStem + "ed" + rest
+			forms[presentParticiple] = ingStem + "ing" + rest
+		}
+		return forms
+	case Adjective:
+		if len(words) > 1 {
+			return nil
+		}
+		var stem string
+		switch {
+		case endsInConsonantY(lemma) && vowelGroups(lemma[:len(lemma)-1]) <= 1:
+			stem = lemma[:len(lemma)-1] + "i"
+		case vowelGroups(strings.TrimSuffix(lemma, "e")) != 1:
+			return nil
+		case strings.HasSuffix(lemma, "e"):
+			stem = lemma[:len(lemma)-1]
+		case endsCVC(lemma):
+			stem = lemma + lemma[len(lemma)-1:]
+		default:
+			stem = lemma
+		}
+		return map[inflection]string{comparative: stem + "er", superlative: stem + "est"}
+	}
+	return nil
+}
+
+// what a verb takes "ed" and "ing" after: "hop" gives "hopp" for both,
+// "hope" "hop" and "hop", "die" "di" and "dy", "panic" "panick".  Not ok
+// if the verb has several syllables and ends in a single vowel and a
+// consonant, which is doubled only when the last syllable is stressed.
+func verbStems(verb string) (pastStem, ingStem string, ok bool) {
+	switch {
+	case strings.HasSuffix(verb, "ie"):
+		return verb[:len(verb)-1], verb[:len(verb)-2] + "y", true
+	case strings.HasSuffix(verb, "ee") || strings.HasSuffix(verb, "ye") || strings.HasSuffix(verb, "oe") || verb == "be":
+		return verb[:len(verb)-1], verb, true
+	case strings.HasSuffix(verb, "e"):
+		return verb[:len(verb)-1], verb[:len(verb)-1], true
+	case endsInConsonantY(verb):
+		return verb[:len(verb)-1] + "i", verb, true
+	case len(verb) > 1 && verb[len(verb)-1] == 'c' && vowelAt(verb, len(verb)-2):
+		return verb + "k", verb + "k", true
+	case endsCVC(verb) && vowelGroups(verb) == 1:
+		doubled := verb + verb[len(verb)-1:]
+		return doubled, doubled, true
+	case endsCVC(verb):
+		return "", "", false
+	}
+	return verb, verb, true
+}
+
+// whether word ends in a consonant other than w, x or y after a single
+// vowel, as "stop", "big" and "quit" do but "rain" and "show" don't
+func endsCVC(word string) bool {
+	n := len(word)
+	return n >= 3 && !vowelAt(word, n-1) && !strings.ContainsRune("wxy", rune(word[n-1])) &&
+		vowelAt(word, n-2) && !vowelAt(word, n-3)
+}
+
+// whether word[i] is a vowel letter: a, e, i, o, u except after q, and y
+// between consonants or after the first letter and a consonant ("gym",
+// "dry")
+func vowelAt(word string, i int) bool {
+	switch word[i] {
+	case 'a', 'e', 'i', 'o':
+		return true
+	case 'u':
+		return i == 0 || word[i-1] != 'q'
+	case 'y':
+		return i > 0 && !vowelAt(word, i-1)
+	}
+	return false
+}
+
+// the number of runs of vowels in word, roughly its syllables
+func vowelGroups(word string) (n int) {
+	for i := range len(word) {
+		if vowelAt(word, i) && (i == 0 || !vowelAt(word, i-1)) {
+			n++
+		}
+	}
+	return n
+}
+
+// the plural of a noun.  Words ending in "man" (or "woman") take "men"
+// ("women") if they are that word or a compound of a noun with it,
+// "firemen" but "humans".
+func (h *Handle) pluralize(word string) string {
+	for _, suffix := range []string{"woman", "man"} {
+		if stem, ok := strings.CutSuffix(word, suffix); ok && (stem == "" || h.isLemma(stem, Noun)) {
+			return stem + strings.TrimSuffix(suffix, "an") + "en"
+		}
+	}
+	return addS(word)
+}
+
+// word with the regular plural or third person singular ending
+func addS(word string) string {
+	switch {
+	case endsInConsonantY(word):
+		return word[:len(word)-1] + "ies"
+	case len(word) > 1 && word[len(word)-1] == 'o' && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word + "es"
+	}
+	for _, sibilant := range []string{"s", "x", "z", "ch", "sh"} {
+		if strings.HasSuffix(word, sibilant) {
+			return word + "es"
+		}
+	}
+	return word + "s"
+}
+
+func endsInConsonantY(word string) bool {
+	return len(word) > 1 && word[len(word)-1] == 'y' && !strings.ContainsRune("aeiou", rune(word[len(word)-2]))
+}
+
+// Guess the lemma and part of speech of word from the parts of speech of
+// the words before and after it, as an upstream tagger gives them (NoPOS
+// for a word of another class, e.g. a pronoun or determiner, or at the
+// start or end of the sentence).  E.g. "saw" is a noun after an adjective
+// ("rusty saw") and a verb after a noun ("the carpenter saw").  This is a
+// few rules of thumb rather than a tagger: the parts of speech word (or a
+// base form MorphWord finds for it) is a lemma of are the candidates, and
+// the first candidate picked by these rules wins:
+//
+//   - after an adjective: Noun
+//   - after an adverb: Verb, then Adjective
+//   - after a noun: Verb
+//   - after a verb: Noun, then Adjective
+//   - before a noun: Adjective, then Verb
+//   - before a verb: Noun
+//   - before an adverb: Verb
+//   - before an adjective: Adverb, then Verb
+//
+// Otherwise the part of speech word is most often used as (see
+// DominantPOS) wins.  The lemma is empty if word isn't in WordNet.  See
+// MorphInWordContext for when the neighbouring words rather than their
+// parts of speech are known.
+func (h *Handle) MorphInContext(word string, prevPOS, nextPOS PartOfSpeech) (lemma string, pos PartOfSpeech) {
+	var preferred []PartOfSpeech
+	switch prevPOS {
+	case Adjective:
+		preferred = append(preferred, Noun)
+	case Adverb:
+		preferred = append(preferred, Verb, Adjective)
+	case Noun:
+		preferred = append(preferred, Verb)
+	case Verb:
+		preferred = append(preferred, Noun, Adjective)
+	}
+	switch nextPOS {
+	case Noun:
+		preferred = append(preferred, Adjective, Verb)
+	case Verb:
+		preferred = append(preferred, Noun)
+	case Adverb:
+		preferred = append(preferred, Verb)
+	case Adjective:
+		preferred = append(preferred, Adverb, Verb)
+	}
+	return h.pickInContext(h.normalizeQuery(word), preferred)
+}
+
+// the lemma word has as the first of preferred parts of speech it is
+// one of, then its dominant part of speech, then any
+func (h *Handle) pickInContext(word string, preferred []PartOfSpeech) (string, PartOfSpeech) {
+	lemmas := map[PartOfSpeech]string{}
+	for _, p := range []PartOfSpeech{Noun, Verb, Adjective, Adverb} {
+		if base := h.MorphWord(word, p); base != "" {
+			lemmas[p] = base
+		} else if h.Contains(word, p) {
+			lemmas[p] = word
+		}
+	}
+	if len(lemmas) == 0 {
+		return "", Noun
+	}
+
+	if dominant, err := h.DominantPOS(word); err == nil {
+		preferred = append(preferred, dominant)
+	}
+	preferred = append(preferred, Noun, Verb, Adjective, Adverb)
+	for _, p := range preferred {
+		if lemma, ok := lemmas[p]; ok {
+			return lemma, p
+		}
+	}
+	return "", Noun
+}
+
+// words after which the next one is most likely a verb (subject pronouns,
+// "to" and auxiliaries) or a noun (determiners and possessives), see
+// MorphInWordContext
+var (
+	verbCues = map[string]bool{}
+	nounCues = map[string]bool{}
+)
+
+func init() {
+	for _, w := range strings.Fields(`i you he she we they to will would shall
+		should can could may might must do does did don't doesn't didn't`) {
+		verbCues[w] = true
+	}
+	for _, w := range strings.Fields(`a an the this that these those my your his
+		her its our their every each some any no`) {
+		nounCues[w] = true
+	}
+}
+
+// Like MorphInContext for callers without a tagger, guessing from the
+// words around word (either may be empty) instead of their parts of
+// speech, e.g. "saw" is a verb after "I" and a noun after "the".  The
+// candidates are the same, the first picked by these rules wins:
+//
+//   - after a subject pronoun, "to" or an auxiliary: Verb
+//   - after a determiner or possessive: Noun or, if next is a noun and not
+//     a verb, Adjective
+//   - after "very", "too", "so" or "quite": Adjective, then Adverb
+//   - before a determiner or object pronoun: Verb
+//
+// Otherwise the part of speech word is most often used as (see
+// DominantPOS) wins.  The lemma is empty if word isn't in WordNet.
+func (h *Handle) MorphInWordContext(word, prev, next string) (lemma string, pos PartOfSpeech) {
+	prev, next = h.normalizeQuery(prev), h.normalizeQuery(next)
+
+	var preferred []PartOfSpeech
+	isNoun := func(w string) bool { return h.Contains(w, Noun) || h.CanMorph(w, Noun) }
+	isVerb := func(w string) bool { return h.Contains(w, Verb) || h.CanMorph(w, Verb) }
+	switch {
+	case verbCues[prev]:
+		preferred = []PartOfSpeech{Verb}
+	case nounCues[prev] && next != "" && isNoun(next) && !isVerb(next):
+		preferred = []PartOfSpeech{Adjective, Noun}
+	case nounCues[prev]:
+		preferred = []PartOfSpeech{Noun}
+	case prev == "very" || prev == "too" || prev == "so" || prev == "quite":
+		preferred = []PartOfSpeech{Adjective, Adverb}
+	case nounCues[next] || next == "me" || next == "him" || next == "us" || next == "them":
+		preferred = []PartOfSpeech{Verb}
+	}
+	return h.pickInContext(h.normalizeQuery(word), preferred)
+}
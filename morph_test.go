@@ -0,0 +1,403 @@
+package wnram
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMorphDetailed(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 06 n 01 ax 0 000 | an edge tool\n" +
+			"00000002 06 n 01 axe 0 000 | an edge tool (british spelling)\n" +
+			"00000003 06 n 01 axis 0 000 | a straight line\n" +
+			"00000004 06 n 01 dog 0 000 | a domestic animal\n" +
+			"00000005 06 n 01 ice_cream 0 000 | a frozen dessert\n",
+		"noun.exc": "axes ax axe axis\n" +
+			"ice_creams ice_cream\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	axes := h.MorphDetailed("axes", Noun)
+	if axes.Base != "ax" || !slices.Equal(axes.Alternatives, []string{"axe", "axis"}) || !axes.Ambiguous {
+		t.Errorf("MorphDetailed(axes) = %+v", axes)
+	}
+	if got := h.MorphWord("axes", Noun); got != axes.Base {
+		t.Errorf("MorphWord(axes) = %q, should match MorphDetailed's base %q", got, axes.Base)
+	}
+
+	dogs := h.MorphDetailed("Dogs", Noun)
+	if dogs.Base != "dog" || len(dogs.Alternatives) != 0 || dogs.Ambiguous {
+		t.Errorf("MorphDetailed(dogs) = %+v", dogs)
+	}
+
+	if got := h.MorphDetailed("ice creams", Noun); got.Base != "ice cream" {
+		t.Errorf("MorphDetailed(ice creams) = %+v", got)
+	}
+	if got := h.MorphDetailed("axes", Verb); got.Base != "" || got.Ambiguous {
+		t.Errorf("noun exceptions applied to verbs: %+v", got)
+	}
+	if got := h.MorphDetailed("dog", Noun); got.Base != "" {
+		t.Errorf("a base form has no base of its own, got %+v", got)
+	}
+
+	// multi-base exception lines resolve to their first base in lookups
+	found, _ := h.Lookup(Criteria{Matching: "axes"})
+	if len(found) != 1 || found[0].Lemma() != "ax" {
+		t.Errorf("unexpected lookup result for axes: %v", found)
+	}
+}
+
+func TestAdverbs(t *testing.T) {
+	found, err := wnInstance.Lookup(Criteria{Matching: "quickly", POS: []PartOfSpeech{Adverb}})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(found) == 0 {
+		t.Fatalf("quickly not found")
+	}
+
+	var pertainyms, antonyms []string
+	for _, f := range found {
+		for _, p := range f.Related(Pertainym) {
+			if p.POS() != Adjective {
+				t.Errorf("pertainym %s of quickly isn't an adjective", p.String())
+			}
+			pertainyms = append(pertainyms, p.Word())
+		}
+		for _, a := range f.Related(Antonym) {
+			if a.POS() != Adverb {
+				t.Errorf("antonym %s of quickly isn't an adverb", a.String())
+			}
+			antonyms = append(antonyms, a.Word())
+		}
+	}
+	if !setContains(pertainyms, []string{"quick"}) {
+		t.Errorf("expected quickly to pertain to quick, got %v", pertainyms)
+	}
+	if !setContains(antonyms, []string{"slowly"}) {
+		t.Errorf("expected slowly among the antonyms of quickly, got %v", antonyms)
+	}
+
+	// and back again
+	slowly, _ := wnInstance.Lookup(Criteria{Matching: "slowly", POS: []PartOfSpeech{Adverb}})
+	var reverse []string
+	for _, s := range slowly {
+		reverse = append(reverse, s.RelatedWords(Antonym)...)
+	}
+	if !setContains(reverse, []string{"quickly"}) {
+		t.Errorf("expected quickly among the antonyms of slowly, got %v", reverse)
+	}
+}
+
+func TestAdverbExceptions(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.adv": "00000001 02 r 01 well 0 000 | in a good or proper or satisfactory manner\n",
+		"adv.exc":  "best well\nbetter well\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	if got := h.MorphWord("better", Adverb); got != "well" {
+		t.Errorf("MorphWord(better, Adverb) = %q; want well", got)
+	}
+	if got := h.MorphWord("better", Noun); got != "" {
+		t.Errorf("adverb exceptions applied to nouns: %q", got)
+	}
+	found, _ := h.Lookup(Criteria{Matching: "best", POS: []PartOfSpeech{Adverb}})
+	if len(found) != 1 {
+		t.Errorf("expected best to be found as well, got %v", found)
+	}
+}
+
+func TestSurfaceForms(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 07 n 01 fire 0 000 | the process of combustion\n",
+		"data.verb": "00000001 42 v 01 be 0 000 | have the quality of being\n",
+		"verb.exc":  "am be\nare be\nbeen be\nbeing be\nis be\nwas be\nwere be\nflew fly\nflown fly\ngone go\nran run\nwent go\n",
+		"noun.exc":  "children child\n",
+		"adj.exc":   "best good\nbetter good\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	if got, want := h.SurfaceForms("be", Verb), []string{"am", "are", "been", "being", "is", "was", "were"}; !slices.Equal(got, want) {
+		t.Errorf("SurfaceForms(be) = %v; want %v", got, want)
+	}
+	if got := h.SurfaceForms("child", Noun); !slices.Equal(got, []string{"children"}) {
+		t.Errorf("an irregular plural should replace the regular one, got %v", got)
+	}
+	h.AddException(Noun, "childs", "child")
+	if got := h.SurfaceForms("child", Noun); !slices.Equal(got, []string{"children", "childs"}) {
+		t.Errorf("user exceptions missing, got %v", got)
+	}
+
+	tests := []struct {
+		lemma    string
+		pos      PartOfSpeech
+		expected []string
+	}{
+		{"dog", Noun, []string{"dogs"}},
+		{"box", Noun, []string{"boxes"}},
+		{"lady", Noun, []string{"ladies"}},
+		{"boy", Noun, []string{"boys"}},
+		{"potato", Noun, []string{"potatoes"}},
+		{"radio", Noun, []string{"radios"}},
+		{"man", Noun, []string{"men"}},
+		{"woman", Noun, []string{"women"}},
+		{"fireman", Noun, []string{"firemen"}},
+		{"human", Noun, []string{"humans"}},
+		{"shaman", Noun, []string{"shamans"}},
+		{"ice cream", Noun, []string{"ice creams"}},
+		{"play", Verb, []string{"played", "playing", "plays"}},
+		{"fly", Verb, []string{"flew", "flies", "flown", "flying"}},
+		{"cry", Verb, []string{"cried", "cries", "crying"}},
+		{"bake", Verb, []string{"baked", "bakes", "baking"}},
+		{"agree", Verb, []string{"agreed", "agreeing", "agrees"}},
+		{"veto", Verb, []string{"vetoed", "vetoes", "vetoing"}},
+		{"look up", Verb, []string{"looked up", "looking up", "looks up"}},
+		{"run", Verb, []string{"ran", "running", "runs"}},
+		{"stop", Verb, []string{"stopped", "stopping", "stops"}},
+		{"hop", Verb, []string{"hopped", "hopping", "hops"}},
+		{"hope", Verb, []string{"hoped", "hopes", "hoping"}},
+		{"panic", Verb, []string{"panicked", "panicking", "panics"}},
+		{"die", Verb, []string{"died", "dies", "dying"}},
+		{"show", Verb, []string{"showed", "showing", "shows"}},
+		// "visited" but "admitted": only the -s form is certain
+		{"visit", Verb, []string{"visits"}},
+		// irregular past forms are combined with the regular -s and -ing
+		// forms
+		{"go", Verb, []string{"goes", "going", "gone", "went"}},
+		{"fast", Adjective, []string{"faster", "fastest"}},
+		{"big", Adjective, []string{"bigger", "biggest"}},
+		{"nice", Adjective, []string{"nicer", "nicest"}},
+		{"happy", Adjective, []string{"happier", "happiest"}},
+		{"good", Adjective, []string{"best", "better"}},
+		{"beautiful", Adjective, nil},
+		{"well known", Adjective, nil},
+		{"quickly", Adverb, nil},
+	}
+	for _, tt := range tests {
+		if got := h.SurfaceForms(tt.lemma, tt.pos); !slices.Equal(got, tt.expected) {
+			t.Errorf("SurfaceForms(%q, %v) = %v; want %v", tt.lemma, tt.pos, got, tt.expected)
+		}
+	}
+	if got := h.SurfaceForms("man", Verb); !slices.Contains(got, "mans") || slices.Contains(got, "men") {
+		t.Errorf("the verb man isn't inflected as a noun, got %v", got)
+	}
+
+	// the bundled data has no verb.exc or adj.exc, so which verbs and
+	// adjectives are irregular isn't known
+	if got := wnInstance.SurfaceForms("go", Verb); got != nil {
+		t.Errorf("SurfaceForms(go, Verb) = %v without verb.exc; want none", got)
+	}
+	if got := wnInstance.SurfaceForms("good", Adjective); got != nil {
+		t.Errorf("SurfaceForms(good, Adjective) = %v without adj.exc; want none", got)
+	}
+	for lemma, want := range map[string][]string{"human": {"humans"}, "shaman": {"shamans"}, "potato": {"potatoes"}, "fireman": {"firemen"}} {
+		if got := wnInstance.SurfaceForms(lemma, Noun); !slices.Equal(got, want) {
+			t.Errorf("SurfaceForms(%q, Noun) = %v; want %v", lemma, got, want)
+		}
+	}
+}
+
+func TestMorphPreserveCase(t *testing.T) {
+	tests := []struct {
+		word     string
+		pos      PartOfSpeech
+		expected string
+	}{
+		{"dogs", Noun, "dog"},
+		{"Dogs", Noun, "Dog"},
+		{"DOGS", Noun, "DOG"},
+		{"Geese", Noun, "Goose"},
+		{"GEESE", Noun, "GOOSE"},
+		{"Walked", Verb, "Walk"},
+		{"Ice Creams", Noun, "Ice Cream"},
+		{"Ice creams", Noun, "Ice cream"},
+		{"dOGs", Noun, "dog"},
+		{"Dog", Noun, ""},
+	}
+	for _, tt := range tests {
+		if got := wnInstance.MorphPreserveCase(tt.word, tt.pos); got != tt.expected {
+			t.Errorf("MorphPreserveCase(%q, %v) = %q; want %q", tt.word, tt.pos, got, tt.expected)
+		}
+	}
+}
+
+func TestSuffixRules(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.adj": "00000001 00 a 01 full 0 000 | containing as much as possible\n" +
+			"00000002 00 a 01 happy 0 000 | enjoying well-being\n",
+		"data.noun": "00000001 07 n 01 fullness 0 000 | the state of being full\n" +
+			"00000002 07 n 01 dog 0 000 | a canine\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	if h.CanMorph("fullness", Noun) || h.CanMorph("a", Noun) || h.CanMorph("quickly", Adverb) {
+		t.Errorf("the built in rules shouldn't reduce fullness, a or quickly")
+	}
+	if !h.CanMorph("dogs", Noun) {
+		t.Errorf("dogs should reduce to dog")
+	}
+
+	h.AddSuffixRule(Noun, "ness", "")
+	h.AddSuffixRule(Noun, "iness", "y")
+	for word, want := range map[string]string{"fullness": "full", "happiness": "happy", "dogs": "dog", "ness": ""} {
+		if got := h.MorphWord(word, Noun); got != want {
+			t.Errorf("MorphWord(%q) = %q; want %q", word, got, want)
+		}
+	}
+	if h.CanMorph("fullness", Verb) {
+		t.Errorf("rules only apply to their part of speech")
+	}
+	// MorphDetailed only reports base forms of the same part of speech
+	if r := h.MorphDetailed("fullness", Noun); r.Base != "" {
+		t.Errorf("full isn't a noun, got %+v", r)
+	}
+}
+
+func TestMorphInContext(t *testing.T) {
+	for _, c := range []struct {
+		word             string
+		prevPOS, nextPOS PartOfSpeech
+		lemma            string
+		pos              PartOfSpeech
+	}{
+		{"saw", Noun, NoPOS, "saw", Verb},
+		{"saw", Adjective, NoPOS, "saw", Noun},
+		{"saw", NoPOS, Verb, "saw", Noun},
+		{"saw", NoPOS, Adverb, "saw", Verb},
+		{"walked", NoPOS, NoPOS, "walk", Verb},
+		{"geese", Adjective, Verb, "goose", Noun},
+		{"fast", Adverb, NoPOS, "fast", Verb},
+		{"red", NoPOS, Noun, "red", Adjective},
+		{"very", NoPOS, Adjective, "very", Adverb},
+		{"xyzzyplugh", Adjective, NoPOS, "", Noun},
+	} {
+		lemma, pos := wnInstance.MorphInContext(c.word, c.prevPOS, c.nextPOS)
+		if lemma != c.lemma || (lemma != "" && pos != c.pos) {
+			t.Errorf("MorphInContext(%q, %s, %s) = %q, %s; want %q, %s", c.word, c.prevPOS, c.nextPOS, lemma, pos, c.lemma, c.pos)
+		}
+	}
+}
+
+func TestMorphNoPOS(t *testing.T) {
+	for _, pos := range []PartOfSpeech{NoPOS, Adverb + 1} {
+		if got := wnInstance.MorphWord("dogs", pos); got != "" {
+			t.Errorf("MorphWord(dogs, %d) = %q; want none", pos, got)
+		}
+		if wnInstance.CanMorph("dogs", pos) {
+			t.Errorf("CanMorph(dogs, %d) = true", pos)
+		}
+		if got := wnInstance.MorphDetailed("dogs", pos); got.Base != "" || len(got.Alternatives) != 0 {
+			t.Errorf("MorphDetailed(dogs, %d) = %+v; want nothing", pos, got)
+		}
+	}
+	if lemma, pos := wnInstance.MorphInContext("dogs", NoPOS, NoPOS); lemma != "dog" || pos != Noun {
+		t.Errorf("MorphInContext(dogs, NoPOS, NoPOS) = %q, %s; want dog, noun", lemma, pos)
+	}
+}
+
+func TestMorphInWordContext(t *testing.T) {
+	for _, c := range []struct {
+		word, prev, next string
+		lemma            string
+		pos              PartOfSpeech
+	}{
+		{"saw", "I", "", "saw", Verb},
+		{"saw", "the", "", "saw", Noun},
+		{"saw", "", "the", "saw", Verb},
+		{"walked", "", "", "walk", Verb},
+		{"geese", "the", "", "goose", Noun},
+		{"fast", "very", "", "fast", Adjective},
+		{"red", "the", "car", "red", Adjective},
+		{"xyzzyplugh", "the", "", "", Noun},
+	} {
+		lemma, pos := wnInstance.MorphInWordContext(c.word, c.prev, c.next)
+		if lemma != c.lemma || (lemma != "" && pos != c.pos) {
+			t.Errorf("MorphInWordContext(%q, %q, %q) = %q, %s; want %q, %s", c.word, c.prev, c.next, lemma, pos, c.lemma, c.pos)
+		}
+	}
+}
+
+func TestMorphPossessivesAndHyphens(t *testing.T) {
+	for _, c := range []struct {
+		word string
+		pos  PartOfSpeech
+		want string
+	}{
+		{"mother's", Noun, "mother"},
+		{"children's", Noun, "child"},
+		{"dogs'", Noun, "dog"},
+		{"geese’s", Noun, "goose"},
+		{"wild-life", Noun, "wildlife"},
+		{"ice-creams", Noun, "icecream"},
+		{"abandoned-ships", Noun, "abandoned ship"},
+		{"well-being", Noun, ""},
+		{"mother's", Verb, ""},
+		{"'s", Noun, ""},
+	} {
+		if got := wnInstance.MorphWord(c.word, c.pos); got != c.want {
+			t.Errorf("MorphWord(%q, %s) = %q; want %q", c.word, c.pos, got, c.want)
+		}
+	}
+
+	found, err := wnInstance.Lookup(Criteria{Matching: "children's", POS: PartOfSpeechList{Noun}})
+	if err != nil || len(found) == 0 || found[0].Matched() != "child" {
+		t.Errorf("expected children's to be found as child, got %v (%v)", found, err)
+	}
+}
+
+func TestDisableMorphExceptions(t *testing.T) {
+	h, err := NewWithOptions(sourceCodeRelPath(PathToWordnetDataFiles), Options{DisableMorphExceptions: true})
+	if err != nil {
+		t.Fatalf("can't load: %s", err)
+	}
+	if got := h.MorphWord("geese", Noun); got != "" {
+		t.Errorf("MorphWord(geese) = %q without exception lists", got)
+	}
+	if got := h.MorphDetailed("geese", Noun); got.Base != "" {
+		t.Errorf("MorphDetailed(geese) = %+v without exception lists", got)
+	}
+	if found, _ := h.Lookup(Criteria{Matching: "geese"}); len(found) != 0 {
+		t.Errorf("expected geese not to be found, got %v", found)
+	}
+	if got := h.MorphWord("dogs", Noun); got != "dog" {
+		t.Errorf("MorphWord(dogs) = %q; the suffix rules should still apply", got)
+	}
+	h.AddException(Noun, "geese", "goose")
+	if got := h.MorphWord("geese", Noun); got != "goose" {
+		t.Errorf("MorphWord(geese) = %q after AddException", got)
+	}
+}
+
+func TestLemmatizeTokens(t *testing.T) {
+	tokens := strings.Fields("the geese were chasing dogs and the dogs barked")
+	got := wnInstance.LemmatizeTokens(tokens, Noun)
+	want := strings.Fields("the goose were chasing dog and the dog barked")
+	if !slices.Equal(got, want) {
+		t.Errorf("LemmatizeTokens = %q; want %q", got, want)
+	}
+	for i, token := range tokens {
+		if base := wnInstance.MorphWord(token, Noun); base != "" && base != got[i] {
+			t.Errorf("%q: LemmatizeTokens gave %q, MorphWord %q", token, got[i], base)
+		}
+	}
+	if got := wnInstance.LemmatizeTokens(nil, Verb); len(got) != 0 {
+		t.Errorf("expected no lemmas, got %q", got)
+	}
+}
+
+func BenchmarkLemmatizeTokens(b *testing.B) {
+	tokens := strings.Fields(strings.Repeat("the quick brown foxes jumped over the lazy dogs while geese watched ", 100))
+	for i := 0; i < b.N; i++ {
+		wnInstance.LemmatizeTokens(tokens, Noun)
+	}
+}
@@ -0,0 +1,65 @@
+package wnram
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The senses of word in every part of speech in the format of the
+// WordNet command line tool's "wn word -over", e.g.
+//
+//	Overview of noun dog
+//
+//	The noun dog has 7 senses (first 1 from tagged texts)
+//
+//	1. (42) dog, domestic dog, Canis familiaris -- (a member of the genus Canis ...)
+//	2. frump, dog -- (a dull unattractive unpleasant girl or woman ...)
+//
+// Senses are in sense number order, prefixed by their tag count when it
+// is known.  Without the sense index (index.sense) there are no sense
+// numbers: the senses are listed in data file order with "-" instead.  An
+// inflected word is reported under its base form.  Empty if word isn't in
+// the database.
+func (h *Handle) Overview(word string) string {
+	var b strings.Builder
+	for _, pos := range []PartOfSpeech{Noun, Verb, Adjective, Adverb} {
+		found, _, err := h.numberedSenses(word, pos)
+		if err != nil || len(found) == 0 {
+			continue
+		}
+		lemma := found[0].Matched()
+		if lemma == "" {
+			lemma = h.normalizeQuery(word)
+		}
+
+		tagged := 0
+		for _, f := range found {
+			if f.tagCount() > 0 {
+				tagged++
+			}
+		}
+		senses := "senses"
+		if len(found) == 1 {
+			senses = "sense"
+		}
+		fmt.Fprintf(&b, "\nOverview of %s %s\n\n", pos, lemma)
+		fmt.Fprintf(&b, "The %s %s has %d %s", pos, lemma, len(found), senses)
+		if tagged > 0 {
+			fmt.Fprintf(&b, " (first %d from tagged texts)", tagged)
+		}
+		b.WriteString("\n\n")
+
+		for i, f := range found {
+			if h.hasFrequencies {
+				fmt.Fprintf(&b, "%d. ", i+1)
+			} else {
+				b.WriteString("- ")
+			}
+			if n := f.tagCount(); n > 0 {
+				fmt.Fprintf(&b, "(%d) ", n)
+			}
+			fmt.Fprintf(&b, "%s -- (%s)\n", strings.Join(f.Synonyms(), ", "), f.Gloss())
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package wnram
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOverview(t *testing.T) {
+	h, err := New(writeDataDir(t, frequencyFixture))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	expected := "\nOverview of noun car\n\n" +
+		"The noun car has 2 senses (first 2 from tagged texts)\n\n" +
+		"1. (40) auto, car, automobile -- (a motor vehicle)\n" +
+		"2. (2) car -- (a wheeled vehicle adapted to the rails of railroad)\n"
+	if got := h.Overview("cars"); got != expected {
+		t.Errorf("unexpected overview:\n%s\nwant:\n%s", got, expected)
+	}
+
+	// without frequencies, for several parts of speech
+	dog := wnInstance.Overview("dog")
+	for _, want := range []string{
+		"\nOverview of noun dog\n\nThe noun dog has 7 senses\n\n- dog, domestic dog, Canis familiaris -- (",
+		"\nOverview of verb dog\n\nThe verb dog has 1 sense\n\n- ",
+	} {
+		if !strings.Contains(dog, want) {
+			t.Errorf("overview of dog lacks %q:\n%s", want, dog)
+		}
+	}
+	if strings.Contains(dog, "Overview of adj") {
+		t.Errorf("dog isn't an adjective")
+	}
+	if got := wnInstance.Overview("xyzzyplugh"); got != "" {
+		t.Errorf("expected an empty overview, got %q", got)
+	}
+}
@@ -168,75 +168,85 @@ func (l *lexable) lexPOS() (PartOfSpeech, error) {
 	return 0, fmt.Errorf("invalid part of speech: %c", curchar)
 }
 
-func (l *lexable) lexRelationType() (Relation, error) {
+// reads a pointer symbol, returning it along with its relation type,
+// which is zero for symbols this package doesn't know
+func (l *lexable) lexRelationType() (Relation, string, error) {
 	l.chomp()
 	word, err := l.lexWord()
 	if err != nil {
-		return 0, fmt.Errorf("can't read relation type: %s", err)
+		return 0, "", fmt.Errorf("can't read relation type: %s", err)
 	}
+	if word == "" {
+		return 0, "", fmt.Errorf("pointer symbol expected")
+	}
+	return symbolRelation(word), word, nil
+}
 
+// the relation type of a pointer symbol, zero if unknown
+func symbolRelation(word string) Relation {
 	switch word {
 	case "!":
-		return Antonym, nil
+		return Antonym
 	case "#m":
-		return MemberHolonym, nil
+		return MemberHolonym
 	case "#p":
-		return PartHolonym, nil
+		return PartHolonym
 	case "#s":
-		return SubstanceHolonym, nil
+		return SubstanceHolonym
 	case "$":
-		return VerbGroup, nil
+		return VerbGroup
 	case "%m":
-		return MemberMeronym, nil
+		return MemberMeronym
 	case "%p":
-		return PartMeronym, nil
+		return PartMeronym
 	case "%s":
-		return SubstanceMeronym, nil
+		return SubstanceMeronym
 	case "&":
-		return SimilarTo, nil
+		return SimilarTo
 	case "*":
-		return Entailment, nil
+		return Entailment
 	case "+":
-		return DerivationallyRelatedForm, nil
+		return DerivationallyRelatedForm
 	case "-c":
-		return InDomainTopic, nil
+		return InDomainTopic
 	case "-r":
-		return InDomainRegion, nil
+		return InDomainRegion
 	case "-u":
-		return InDomainUsage, nil
+		return InDomainUsage
 	case ";c":
-		return ContainsDomainTopic, nil
+		return ContainsDomainTopic
 	case ";r":
-		return ContainsDomainRegion, nil
+		return ContainsDomainRegion
 	case ";u":
-		return ContainsDomainUsage, nil
+		return ContainsDomainUsage
 	case "<":
-		return ParticipleOfVerb, nil
+		return ParticipleOfVerb
 	case "=":
-		return Attribute, nil
+		return Attribute
 	case ">":
-		return Cause, nil
+		return Cause
 	case "@":
-		return Hypernym, nil
+		return Hypernym
 	case "@i":
-		return InstanceHypernym, nil
+		return InstanceHypernym
 	case "\\":
 		// also DerivedFromAdjective
-		return Pertainym, nil
+		return Pertainym
 	case "^":
-		return AlsoSee, nil
+		return AlsoSee
 	case "~":
-		return Hyponym, nil
+		return Hyponym
 	case "~i":
-		return InstanceHyponym, nil
+		return InstanceHyponym
 	}
 
-	return 0, fmt.Errorf("unrecognized pointer type: %q", word)
+	return 0
 }
 
 type parsedRel struct {
 	pos          PartOfSpeech
 	rel          Relation
+	symbol       string
 	offset       string
 	isSemantic   bool
 	source, dest uint8
@@ -245,12 +255,24 @@ type parsedRel struct {
 type parsed struct {
 	byteOffset string
 	pos        PartOfSpeech
+	satellite  bool // an adjective satellite (ss_type s)
 	fileNum    int64
 	words      []word
 	gloss      string
 	rels       []parsedRel
 }
 
+// splitMarker separates an adjective's syntactic marker from its lemma,
+// e.g. "galore(ip)" gives "galore" and "ip"
+func splitMarker(lemma string) (string, string) {
+	for _, marker := range []string{"a", "p", "ip"} {
+		if base, ok := strings.CutSuffix(lemma, "("+marker+")"); ok && base != "" {
+			return base, marker
+		}
+	}
+	return lemma, ""
+}
+
 func parseLine(data []byte, line int64) (*parsed, error) {
 	l := lexable(data)
 
@@ -272,6 +294,9 @@ func parseLine(data []byte, line int64) (*parsed, error) {
 		return nil, fmt.Errorf("filenumber expected: %s", err)
 	}
 
+	// satellites are read as adjectives, remember which they were
+	l.chomp()
+	satellite := strings.HasPrefix(string(l), "s")
 	pos, err := l.lexPOS()
 	if err != nil {
 		return nil, fmt.Errorf("part of speech expected: %s", err)
@@ -286,6 +311,7 @@ func parseLine(data []byte, line int64) (*parsed, error) {
 	p := parsed{
 		byteOffset: byteOffset,
 		pos:        pos,
+		satellite:  satellite,
 		fileNum:    filenum,
 	}
 
@@ -294,14 +320,15 @@ func parseLine(data []byte, line int64) (*parsed, error) {
 		if err != nil {
 			return nil, fmt.Errorf("word expected: %s", err)
 		}
-		// XXX: handle syntactic markers
+		value, marker := splitMarker(value)
 		sense, err := l.lexHexNumber()
 		if err != nil {
 			return nil, fmt.Errorf("sense id expected: %s", err)
 		}
 		p.words = append(p.words, word{
-			word:  value,
-			sense: uint8(sense),
+			word:   value,
+			marker: marker,
+			sense:  uint8(sense),
 		})
 	}
 
@@ -311,7 +338,7 @@ func parseLine(data []byte, line int64) (*parsed, error) {
 	}
 
 	for ; pcount > 0; pcount-- {
-		if rt, err := l.lexRelationType(); err != nil {
+		if rt, symbol, err := l.lexRelationType(); err != nil {
 			return nil, err
 		} else if offset, err := l.lexOffset(); err != nil {
 			return nil, err
@@ -322,6 +349,7 @@ func parseLine(data []byte, line int64) (*parsed, error) {
 		} else {
 			r := parsedRel{
 				rel:    rt,
+				symbol: symbol,
 				pos:    pos,
 				offset: offset,
 			}
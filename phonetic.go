@@ -0,0 +1,184 @@
+package wnram
+
+import (
+	"slices"
+	"strings"
+)
+
+// Words that sound like word, found by comparing their Metaphone codes
+// (Lawrence Philips' original 1990 algorithm, which also drops the silent
+// first letter of "mn").  E.g. "nee-mo-nik" finds "mnemonic".  Results
+// are normalized lemmas in alphabetical order.  Requires the handle to be
+// created with Options.PhoneticIndex, returns nil otherwise.
+func (h *Handle) SoundsLike(word string) []string {
+	if h.phonetic == nil {
+		return nil
+	}
+	code := metaphone(word)
+	if code == "" {
+		return nil
+	}
+	return slices.Clone(h.phonetic[code])
+}
+
+func (h *Handle) buildPhoneticIndex() {
+	h.phonetic = map[string][]string{}
+	for lemma := range h.index {
+		if code := metaphone(lemma); code != "" {
+			h.phonetic[code] = append(h.phonetic[code], lemma)
+		}
+	}
+	for _, lemmas := range h.phonetic {
+		slices.Sort(lemmas)
+	}
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("AEIOU", c) >= 0
+}
+
+// metaphone computes the Metaphone code of in, ignoring anything that
+// isn't an ASCII letter
+func metaphone(in string) string {
+	// keep letters only, upper cased, and collapse doubled letters other
+	// than C
+	var w []byte
+	for i := 0; i < len(in); i++ {
+		c := in[i]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		if c < 'A' || c > 'Z' {
+			continue
+		}
+		if len(w) > 0 && w[len(w)-1] == c && c != 'C' {
+			continue
+		}
+		w = append(w, c)
+	}
+	if len(w) == 0 {
+		return ""
+	}
+
+	// initial exceptions
+	switch {
+	case len(w) > 1 && strings.Contains("AE GN KN PN WR MN", string(w[:2])):
+		w = w[1:]
+	case w[0] == 'X':
+		w[0] = 'S'
+	case len(w) > 1 && w[0] == 'W' && w[1] == 'H':
+		w = append([]byte{'W'}, w[2:]...)
+	}
+
+	at := func(i int) byte {
+		if i < 0 || i >= len(w) {
+			return 0
+		}
+		return w[i]
+	}
+	frontVowel := func(c byte) bool { return c == 'E' || c == 'I' || c == 'Y' }
+
+	var code strings.Builder
+	for i := 0; i < len(w); i++ {
+		c := w[i]
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U':
+			if i == 0 {
+				code.WriteByte(c)
+			}
+		case 'B':
+			if i != len(w)-1 || at(i-1) != 'M' {
+				code.WriteByte('B')
+			}
+		case 'C':
+			switch {
+			case at(i+1) == 'I' && at(i+2) == 'A':
+				code.WriteByte('X')
+			case at(i+1) == 'H':
+				if at(i-1) == 'S' {
+					code.WriteByte('K')
+				} else {
+					code.WriteByte('X')
+				}
+				i++
+			case frontVowel(at(i + 1)):
+				if at(i-1) != 'S' {
+					code.WriteByte('S')
+				}
+			default:
+				code.WriteByte('K')
+			}
+		case 'D':
+			if at(i+1) == 'G' && frontVowel(at(i+2)) {
+				code.WriteByte('J')
+				i++
+			} else {
+				code.WriteByte('T')
+			}
+		case 'G':
+			switch {
+			case at(i+1) == 'H' && i+2 < len(w) && !isVowel(at(i+2)):
+				// silent as in "night"
+			case at(i+1) == 'N' && (i+2 == len(w) || (at(i+2) == 'E' && at(i+3) == 'D' && i+4 == len(w))):
+				// silent as in "sign", "signed"
+			case frontVowel(at(i + 1)):
+				code.WriteByte('J')
+			default:
+				code.WriteByte('K')
+			}
+		case 'H':
+			if isVowel(at(i+1)) && !strings.ContainsRune("CSPTG", rune(at(i-1))) {
+				code.WriteByte('H')
+			}
+		case 'K':
+			if at(i-1) != 'C' {
+				code.WriteByte('K')
+			}
+		case 'P':
+			if at(i+1) == 'H' {
+				code.WriteByte('F')
+			} else {
+				code.WriteByte('P')
+			}
+		case 'Q':
+			code.WriteByte('K')
+		case 'S':
+			switch {
+			case at(i+1) == 'H':
+				code.WriteByte('X')
+				i++
+			case at(i+1) == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				code.WriteByte('X')
+			default:
+				code.WriteByte('S')
+			}
+		case 'T':
+			switch {
+			case at(i+1) == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				code.WriteByte('X')
+			case at(i+1) == 'H':
+				code.WriteByte('0')
+				i++
+			case at(i+1) == 'C' && at(i+2) == 'H':
+				// silent as in "watch"
+			default:
+				code.WriteByte('T')
+			}
+		case 'V':
+			code.WriteByte('F')
+		case 'W', 'Y':
+			if isVowel(at(i + 1)) {
+				code.WriteByte(c)
+			}
+		case 'X':
+			code.WriteString("KS")
+		case 'Z':
+			code.WriteByte('S')
+		default:
+			// F, J, L, M, N, R
+			code.WriteByte(c)
+		}
+	}
+
+	return code.String()
+}
@@ -0,0 +1,53 @@
+package wnram
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMetaphone(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		{"mnemonic", "NMNK"},
+		{"nee-mo-nik", "NMNK"},
+		{"knight", "NT"},
+		{"night", "NT"},
+		{"phone", "FN"},
+		{"thumb", "0M"},
+		{"school", "SKL"},
+		{"church", "XRX"},
+		{"xylophone", "SLFN"},
+		{"whale", "WL"},
+		{"judge", "JJ"},
+		{"nation", "NXN"},
+		{"ice cream", "ISKRM"},
+		{"", ""},
+		{"123", ""},
+	}
+
+	for _, tt := range tests {
+		if got := metaphone(tt.word); got != tt.expected {
+			t.Errorf("metaphone(%q) = %q; want %q", tt.word, got, tt.expected)
+		}
+	}
+}
+
+func TestSoundsLike(t *testing.T) {
+	if got := wnInstance.SoundsLike("nee-mo-nik"); got != nil {
+		t.Errorf("expected nil without a phonetic index, got %v", got)
+	}
+
+	h, err := NewWithOptions(sourceCodeRelPath(PathToWordnetDataFiles), Options{PhoneticIndex: true})
+	if err != nil {
+		t.Fatalf("can't load: %s", err)
+	}
+
+	if got := h.SoundsLike("nee-mo-nik"); !slices.Contains(got, "mnemonic") {
+		t.Errorf("SoundsLike(nee-mo-nik) = %v; want mnemonic among them", got)
+	}
+	if got := h.SoundsLike("fone"); !slices.Contains(got, "phone") {
+		t.Errorf("SoundsLike(fone) = %v; want phone among them", got)
+	}
+}
@@ -0,0 +1,49 @@
+package wnram
+
+// A pointer of a synset as listed in its data file line
+type Pointer struct {
+	Symbol   string // e.g. "@" for a hypernym
+	TargetID string // see Lookup.SynsetID
+	// One based numbers of the source and target members of a lexical
+	// pointer, both zero for a pointer between whole synsets
+	SourceWordIndex, TargetWordIndex int
+}
+
+// a pointer whose relation type isn't modeled
+type unknownPointer struct {
+	symbol       string
+	target       *cluster
+	source, dest int
+}
+
+// All pointers of this synset, including those with symbols that have no
+// Relation (which Related can't follow), for custom graph analysis.
+// Pointers between whole synsets come first, then the lexical pointers
+// of each member in turn, then the unknown ones, each group in data file
+// order.  The result is a copy.
+func (w *Lookup) Pointers() []Pointer {
+	c := w.synset()
+	pointers := []Pointer{}
+	for _, rel := range c.relations {
+		pointers = append(pointers, Pointer{Symbol: relationSymbols[rel.rel], TargetID: rel.target.id()})
+	}
+	for i, m := range c.words {
+		for _, rel := range m.relations {
+			pointers = append(pointers, Pointer{
+				Symbol:          relationSymbols[rel.rel],
+				TargetID:        rel.target.id(),
+				SourceWordIndex: i + 1,
+				TargetWordIndex: int(rel.wordNumber) + 1,
+			})
+		}
+	}
+	for _, p := range c.unknownPointers {
+		pointers = append(pointers, Pointer{
+			Symbol:          p.symbol,
+			TargetID:        p.target.id(),
+			SourceWordIndex: p.source,
+			TargetWordIndex: p.dest,
+		})
+	}
+	return pointers
+}
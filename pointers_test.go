@@ -0,0 +1,45 @@
+package wnram
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPointers(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 02 widget 0 gizmo 0 003 @ 00000002 n 0000 ?x 00000002 n 0000 + 00000002 n 0201 | a small gadget\n" +
+			"00000002 03 n 01 device 0 001 ~ 00000001 n 0000 | an instrumentality\n",
+	}))
+	if err != nil {
+		t.Fatalf("unknown pointer symbols should load: %s", err)
+	}
+	widget := firstSense(t, h, "widget", Noun)
+	expected := []Pointer{
+		{Symbol: "@", TargetID: "00000002-n"},
+		{Symbol: "+", TargetID: "00000002-n", SourceWordIndex: 2, TargetWordIndex: 1},
+		{Symbol: "?x", TargetID: "00000002-n"},
+	}
+	if got := widget.Pointers(); !slices.Equal(got, expected) {
+		t.Errorf("Pointers() = %+v; want %+v", got, expected)
+	}
+	if rel := widget.Related(Hypernym | Hyponym | DerivationallyRelatedForm); len(rel) != 1 {
+		t.Errorf("the unknown pointer shouldn't be a relation, got %d", len(rel))
+	}
+
+	// every relation of the real database has a symbol
+	err = wnInstance.Iterate(PartOfSpeechList{Adjective, Adverb}, func(l Lookup) error {
+		for _, p := range l.Pointers() {
+			if p.Symbol == "" || p.TargetID == "" {
+				t.Fatalf("%s: incomplete pointer %+v", l.SynsetID(), p)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	dog := specificSense(t, "dog", Noun, "domesticated")
+	if got, want := len(dog.Pointers()), dog.RelationCount(^Relation(0)); got < want {
+		t.Errorf("dog has %d pointers but %d relations", got, want)
+	}
+}
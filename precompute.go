@@ -0,0 +1,138 @@
+package wnram
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// A derived data set that some methods need and that Precompute can build
+// ahead of time
+type Feature int
+
+const (
+	// The depth of every noun and verb synset in its hierarchy, and the
+	// greatest depth per part of speech.  Used by WuPalmerSimilarity,
+	// LeacockChodorow and Lookup.Specificity.
+	FeatureDepths Feature = iota
+	// The information content of every noun and verb synset.  Used by
+	// InformationContent and the Resnik, Lin and JiangConrath measures.
+	FeatureIC
+)
+
+// lazily computed data shared by the similarity measures, which every
+// synset of the handle points to for the Lookup methods that need it
+type derived struct {
+	synsets []*cluster // the handle's, the data sets are built from
+
+	depthsOnce sync.Once
+	depths     map[*cluster]int
+	maxDepths  map[PartOfSpeech]int
+
+	icOnce sync.Once
+	ic     map[*cluster]float64
+}
+
+// Build the given derived data sets now rather than on first use, to move
+// their one-time cost (up to a second each) to startup.  Methods
+// that need a feature build it on demand if it wasn't precomputed.  Safe
+// to call concurrently and more than once.
+func (h *Handle) Precompute(features ...Feature) error {
+	for _, f := range features {
+		switch f {
+		case FeatureDepths:
+			h.derived.ensureDepths()
+		case FeatureIC:
+			h.ensureIC()
+		default:
+			return fmt.Errorf("unknown feature %d", f)
+		}
+	}
+	return nil
+}
+
+func (d *derived) ensureDepths() {
+	d.depthsOnce.Do(func() {
+		d.depths = make(map[*cluster]int)
+		d.maxDepths = make(map[PartOfSpeech]int)
+		for _, c := range d.synsets {
+			if c.pos == Noun || c.pos == Verb {
+				depth := c.minDepth()
+				d.depths[c] = depth
+				d.maxDepths[c.pos] = max(d.maxDepths[c.pos], depth)
+			}
+		}
+	})
+}
+
+// the precomputed depth of c, see Lookup.Depth
+func (h *Handle) depth(c *cluster) int {
+	h.derived.ensureDepths()
+	return h.derived.depths[c]
+}
+
+func (h *Handle) ensureIC() {
+	h.derived.icOnce.Do(func() {
+		freq := make(map[*cluster]float64)
+		total := make(map[PartOfSpeech]float64)
+		for _, c := range h.db {
+			if c.pos != Noun && c.pos != Verb {
+				continue
+			}
+			count := 1.0
+			for _, w := range c.words {
+				count += float64(w.tagCount)
+			}
+			total[c.pos] += count
+			for a := range ancestors(c) {
+				freq[a] += count
+			}
+		}
+
+		h.derived.ic = make(map[*cluster]float64, len(freq))
+		for c, f := range freq {
+			ic := -math.Log(f / total[c.pos])
+			if ic == 0 {
+				// -log(1) is -0 for the roots, which prints as "-0"
+				ic = 0
+			}
+			h.derived.ic[c] = ic
+		}
+	})
+}
+
+// The information content of a noun or verb synset, -log(p(c)), where
+// p(c) is the probability of meeting c or any of its hyponyms.  Each
+// synset counts the tags of its members from index.sense plus one (so
+// without frequency data every synset counts once and IC only reflects
+// the shape of the hierarchy), and that count is added to every hypernym.
+// Zero for the root of the noun hierarchy, growing as synsets get more
+// specific.
+func (h *Handle) InformationContent(l Lookup) (float64, error) {
+	if l.cluster == nil {
+		return 0, fmt.Errorf("the zero Lookup has no information content")
+	}
+	if l.cluster.pos != Noun && l.cluster.pos != Verb {
+		return 0, fmt.Errorf("%ss have no hypernym hierarchy", l.cluster.pos)
+	}
+	h.ensureIC()
+	return h.derived.ic[l.cluster], nil
+}
+
+// How specific this synset is, its Depth over the greatest depth of a
+// synset of its part of speech: zero for the roots of the hierarchy
+// ("entity") and one for the deepest synsets, e.g. for filtering out
+// overly abstract concepts.  Uses FeatureDepths of the handle the synset
+// was loaded by.  Zero for adjectives and adverbs, which have no
+// hierarchy, and for the zero Lookup.
+func (w *Lookup) Specificity() float64 {
+	c := w.synset()
+	if (c.pos != Noun && c.pos != Verb) || c.derived == nil {
+		return 0
+	}
+	c.derived.ensureDepths()
+	if c.derived.maxDepths[c.pos] == 0 {
+		return 0
+	}
+	return float64(c.derived.depths[c]) / float64(c.derived.maxDepths[c.pos])
+}
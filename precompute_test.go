@@ -0,0 +1,70 @@
+package wnram
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPrecompute(t *testing.T) {
+	h, err := New(sourceCodeRelPath(PathToWordnetDataFiles))
+	if err != nil {
+		t.Fatalf("can't load: %s", err)
+	}
+	if err := h.Precompute(Feature(42)); err == nil {
+		t.Errorf("expected an error for an unknown feature")
+	}
+
+	start := time.Now()
+	if err := h.Precompute(FeatureDepths, FeatureIC); err != nil {
+		t.Fatalf("%s", err)
+	}
+	t.Logf("precomputed in %s", time.Since(start))
+
+	entity := h.Roots(Noun)[0]
+	dog, cat := firstSense(t, h, "dog", Noun), firstSense(t, h, "cat", Noun)
+	for _, l := range []Lookup{entity, dog, cat} {
+		if got := h.depth(l.cluster); got != l.Depth() {
+			t.Errorf("precomputed depth of %s = %d; want %d", l.SynsetID(), got, l.Depth())
+		}
+	}
+
+	if ic, err := h.InformationContent(entity); err != nil || ic != 0 {
+		t.Errorf("information content of entity = %v, %v; want 0", ic, err)
+	}
+	animal := firstSense(t, h, "animal", Noun)
+	animalIC, _ := h.InformationContent(animal)
+	dogIC, _ := h.InformationContent(dog)
+	if animalIC <= 0 || dogIC <= animalIC {
+		t.Errorf("expected 0 < IC(animal) = %v < IC(dog) = %v", animalIC, dogIC)
+	}
+	if _, err := h.InformationContent(firstSense(t, h, "good", Adjective)); err == nil {
+		t.Errorf("expected an error for adjectives")
+	}
+}
+
+func TestSpecificity(t *testing.T) {
+	entity := wnInstance.Roots(Noun)[0]
+	if s := entity.Specificity(); s != 0 {
+		t.Errorf("specificity of entity = %f; want 0", s)
+	}
+	dog := firstSense(t, wnInstance, "dog", Noun)
+	animal := firstSense(t, wnInstance, "animal", Noun)
+	if s, a := dog.Specificity(), animal.Specificity(); a >= s || s >= 1 {
+		t.Errorf("expected 0 < animal (%f) < dog (%f) < 1", a, s)
+	}
+
+	deepest := 0.0
+	wnInstance.Iterate(PartOfSpeechList{Verb}, func(l Lookup) error {
+		deepest = max(deepest, l.Specificity())
+		return nil
+	})
+	if deepest != 1 {
+		t.Errorf("expected the deepest verb to have specificity 1, got %f", deepest)
+	}
+
+	var zero Lookup
+	good := firstSense(t, wnInstance, "good", Adjective)
+	if s := good.Specificity() + zero.Specificity(); s != 0 {
+		t.Errorf("expected zero specificity without a hierarchy, got %f", s)
+	}
+}
@@ -2,16 +2,22 @@ package wnram
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"os"
 )
 
+var byteOrderMark = []byte{0xef, 0xbb, 0xbf}
+
 // InPlaceReadLine scans a file and invoke the provided callback for
 // every line read.  Because scanning a file for newline delimiters is
 // an incredibly cheap operation, the overhead of multi-thread
 // communication can be slower than processing on a single thread when
 // the per line computational cost is low.
+//
+// A leading UTF-8 byte order mark and CRLF line endings are dropped, the
+// reported offsets still count them.
 func inPlaceReadLine(s io.Reader, cb func([]byte, int64, int64) error) error {
 	const bufSize = 8396800 // 8 meg
 	reader := bufio.NewReaderSize(s, bufSize)
@@ -19,8 +25,16 @@ func inPlaceReadLine(s io.Reader, cb func([]byte, int64, int64) error) error {
 	var offset int64
 	var err error
 	var line []byte
+
+	if bom, _ := reader.Peek(len(byteOrderMark)); bytes.Equal(bom, byteOrderMark) {
+		if _, err = reader.Discard(len(byteOrderMark)); err != nil {
+			return err
+		}
+		offset += int64(len(byteOrderMark))
+	}
+
 	for line, err = reader.ReadSlice('\n'); err == nil; line, err = reader.ReadSlice('\n') {
-		if err = cb(line[:len(line)-1], count, offset); err != nil {
+		if err = cb(bytes.TrimSuffix(line[:len(line)-1], []byte{'\r'}), count, offset); err != nil {
 			return err
 		}
 		offset += int64(len(line))
@@ -30,7 +44,7 @@ func inPlaceReadLine(s io.Reader, cb func([]byte, int64, int64) error) error {
 	// If we reached end of file and the line contents are empty, don't return an additional line.
 	if err == io.EOF {
 		if len(line) > 0 {
-			return cb(line, count, offset)
+			return cb(bytes.TrimSuffix(line, []byte{'\r'}), count, offset)
 		}
 	} else {
 		return cb(line, count, offset)
@@ -0,0 +1,48 @@
+package wnram
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInPlaceReadLineBOMAndCRLF(t *testing.T) {
+	input := "\xef\xbb\xbffirst line\r\nsecond line\r\nthird line\r"
+	var lines []string
+	var offsets []int64
+	err := inPlaceReadLine(strings.NewReader(input), func(data []byte, line, offset int64) error {
+		lines = append(lines, string(data))
+		offsets = append(offsets, offset)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("inPlaceReadLine failed: %s", err)
+	}
+
+	expected := []string{"first line", "second line", "third line"}
+	if strings.Join(lines, "|") != strings.Join(expected, "|") {
+		t.Errorf("got lines %q; want %q", lines, expected)
+	}
+	if offsets[0] != 3 || offsets[1] != 15 {
+		t.Errorf("unexpected offsets %v", offsets)
+	}
+}
+
+func TestParsingWindowsDataFiles(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "\xef\xbb\xbf00000001 03 n 01 widget 0 000 | a small gadget\r\n" +
+			"00000002 03 n 01 gizmo 0 001 @ 00000001 n 0000 | a kind of widget\r\n",
+		"noun.exc": "\xef\xbb\xbfwidgeta widget\r\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load CRLF data: %s", err)
+	}
+
+	found, _ := h.Lookup(Criteria{Matching: "widgeta"})
+	if len(found) != 1 || found[0].Gloss() != "a small gadget" {
+		t.Fatalf("unexpected lookup result for widget: %v", found)
+	}
+	found, _ = h.Lookup(Criteria{Matching: "gizmo"})
+	if len(found) != 1 || len(found[0].Related(Hypernym)) != 1 {
+		t.Errorf("relations not parsed from CRLF data: %v", found)
+	}
+}
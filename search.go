@@ -0,0 +1,59 @@
+package wnram
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The pos synsets having a member that contains substr anywhere, not
+// just as a prefix, e.g. "quick", "equal" and "mosque" for "qu".
+// Matching is case insensitive, with spaces and underscores equivalent
+// as in Lookup.  Each synset is reported once, searched for as its first
+// matching member, in data file order.  This scans all lemmas, which
+// takes tens of milliseconds.
+func (h *Handle) SensesContaining(substr string, pos PartOfSpeech) []Lookup {
+	substr = h.normalizeQuery(substr)
+	if substr == "" {
+		return nil
+	}
+
+	matches := map[*cluster]bool{}
+	for lemma, clusters := range h.index {
+		if strings.Contains(lemma, substr) {
+			for _, c := range clusters {
+				if c.pos == pos {
+					matches[c] = true
+				}
+			}
+		}
+	}
+
+	var found []Lookup
+	for _, c := range h.db {
+		if !matches[c] {
+			continue
+		}
+		for _, m := range c.words {
+			if strings.Contains(normalize(m.word), substr) {
+				found = append(found, Lookup{word: m.word, cluster: c})
+				break
+			}
+		}
+	}
+	return found
+}
+
+// The pos synsets whose gloss (definition and examples) re matches, in
+// data file order, each searched for as its lemma, e.g. `^a young \w+`
+// finds "puppy", "foal" and other young animals.  This runs re on every
+// pos gloss, so its cost depends on the expression: a literal prefix
+// search over the nouns takes a few tens of milliseconds.
+func (h *Handle) GlossRegexp(re *regexp.Regexp, pos PartOfSpeech) []Lookup {
+	var found []Lookup
+	for _, c := range h.db {
+		if c.pos == pos && re.MatchString(c.gloss) {
+			found = append(found, Lookup{word: c.words[0].word, cluster: c})
+		}
+	}
+	return found
+}
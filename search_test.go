@@ -0,0 +1,68 @@
+package wnram
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSensesContaining(t *testing.T) {
+	found := wnInstance.SensesContaining("QU", Noun)
+	var words []string
+	ids := map[string]bool{}
+	for _, f := range found {
+		if !strings.Contains(strings.ToLower(f.Word()), "qu") || f.POS() != Noun {
+			t.Errorf("unexpected result %s", f.Canonical())
+		}
+		if ids[f.SynsetID()] {
+			t.Errorf("%s reported twice", f.Canonical())
+		}
+		ids[f.SynsetID()] = true
+		words = append(words, f.Word())
+	}
+	if !setContains(words, []string{"quack", "mosque", "equality"}) {
+		t.Errorf("missing words containing qu in %d results", len(words))
+	}
+
+	if got := wnInstance.SensesContaining("ice_cr", Noun); !slices.ContainsFunc(got, func(l Lookup) bool { return l.Word() == "ice cream" }) {
+		t.Errorf("underscores should match spaces, got %v", got)
+	}
+	if got := wnInstance.SensesContaining("", Noun); got != nil {
+		t.Errorf("expected nothing for an empty substring, got %d results", len(got))
+	}
+	if got := wnInstance.SensesContaining("xyzzyplugh", Noun); len(got) != 0 {
+		t.Errorf("expected no results, got %v", got)
+	}
+}
+
+func BenchmarkSensesContaining(b *testing.B) {
+	for range b.N {
+		wnInstance.SensesContaining("qu", Noun)
+	}
+}
+
+func TestGlossRegexp(t *testing.T) {
+	re := regexp.MustCompile(`^a young \w+`)
+	found := wnInstance.GlossRegexp(re, Noun)
+	var words []string
+	for _, f := range found {
+		if !re.MatchString(f.Gloss()) || f.POS() != Noun {
+			t.Errorf("unexpected result %s: %s", f.Canonical(), f.Gloss())
+		}
+		words = append(words, f.Word())
+	}
+	if !setContains(words, []string{"puppy", "foal"}) {
+		t.Errorf("young animals missing from %v", words)
+	}
+	if got := wnInstance.GlossRegexp(re, Adverb); len(got) != 0 {
+		t.Errorf("expected no adverbs, got %v", got)
+	}
+}
+
+func BenchmarkGlossRegexp(b *testing.B) {
+	re := regexp.MustCompile(`^a young \w+`)
+	for range b.N {
+		wnInstance.GlossRegexp(re, Noun)
+	}
+}
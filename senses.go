@@ -0,0 +1,479 @@
+package wnram
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// A parsed line of the optional index.sense file
+type senseEntry struct {
+	lemma       string
+	pos         PartOfSpeech
+	offset      string
+	senseNumber int
+	tagCount    int
+}
+
+// parseSenseLine parses a line of index.sense, which has the form
+// "sense_key synset_offset sense_number tag_cnt", where sense_key is
+// "lemma%ss_type:lex_filenum:lex_id:head_word:head_id"
+func parseSenseLine(data []byte) (*senseEntry, error) {
+	fields := strings.Fields(string(data))
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("expected 4 fields in sense index line, got %d", len(fields))
+	}
+
+	lemma, rest, ok := strings.Cut(fields[0], "%")
+	if !ok || len(rest) == 0 {
+		return nil, fmt.Errorf("malformed sense key: %q", fields[0])
+	}
+
+	pos, err := ssTypePOS(rest[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed sense key %q: %s", fields[0], err)
+	}
+
+	senseNumber, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed sense number: %s", err)
+	}
+
+	tagCount, err := strconv.Atoi(fields[3])
+	if err != nil {
+		return nil, fmt.Errorf("malformed tag count: %s", err)
+	}
+
+	return &senseEntry{
+		lemma:       strings.ReplaceAll(lemma, "_", " "),
+		pos:         pos,
+		offset:      fields[1],
+		senseNumber: senseNumber,
+		tagCount:    tagCount,
+	}, nil
+}
+
+// the part of speech encoded by the ss_type digit of a sense key
+func ssTypePOS(ssType byte) (PartOfSpeech, error) {
+	switch ssType {
+	case '1':
+		return Noun, nil
+	case '2':
+		return Verb, nil
+	case '3', '5':
+		return Adjective, nil
+	case '4':
+		return Adverb, nil
+	}
+	return 0, fmt.Errorf("invalid synset type: %c", ssType)
+}
+
+// the sense key of member i of c, e.g. "dog%1:05:00::", computed from the
+// data files.  The head word and id fields are only set for adjective
+// satellites, naming the first member of their head synset.
+func (c *cluster) senseKey(i int) string {
+	m := c.words[i]
+	ssType := int(c.pos) + 1
+	head := ":"
+	if c.satellite {
+		ssType = 5
+		for _, rel := range c.relations {
+			if rel.rel == SimilarTo && !rel.target.satellite && len(rel.target.words) > 0 {
+				hw := rel.target.words[0]
+				head = fmt.Sprintf("%s:%02d", senseKeyLemma(hw.word), hw.sense)
+				break
+			}
+		}
+	}
+	return fmt.Sprintf("%s%%%d:%02d:%02d:%s", senseKeyLemma(m.word), ssType, c.lexFile, m.sense, head)
+}
+
+// lemmas are lower case in sense keys, with underscores between words
+func senseKeyLemma(lemma string) string {
+	return strings.ReplaceAll(strings.ToLower(lemma), " ", "_")
+}
+
+// apply attaches the sense number and tag count of e to the matching
+// member of c
+func (e *senseEntry) apply(c *cluster) error {
+	for i := range c.words {
+		if normalize(c.words[i].word) == e.lemma {
+			c.words[i].senseNumber = e.senseNumber
+			c.words[i].tagCount = e.tagCount
+			return nil
+		}
+	}
+	return fmt.Errorf("sense index refers to %q, which is not a member of synset %s", e.lemma, c.id())
+}
+
+// The results of looking up word as any of pos, ordered so that the
+// meanings the word was most often tagged with come first.  Without
+// frequency data the lookup order is kept.
+func (h *Handle) sensesByFrequency(word string, pos PartOfSpeechList) ([]Lookup, error) {
+	found, err := h.Lookup(Criteria{Matching: word, POS: pos})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(found, func(a, b Lookup) int {
+		return b.tagCount() - a.tagCount()
+	})
+	return found, nil
+}
+
+// the sense number of the searched word in this meaning, zero if unknown
+func (w *Lookup) senseNumber() int {
+	if m := w.member(); m != nil {
+		return m.senseNumber
+	}
+	return 0
+}
+
+// The senses of word as pos ordered by sense number, along with those
+// numbers.  Senses the sense index doesn't number (or all of them, when
+// it isn't loaded) follow in lookup order and are numbered by position.
+func (h *Handle) numberedSenses(word string, pos PartOfSpeech) ([]Lookup, []int, error) {
+	found, err := h.Lookup(Criteria{Matching: word, POS: PartOfSpeechList{pos}})
+	if err != nil {
+		return nil, nil, err
+	}
+	return found, numberSenses(found), nil
+}
+
+// sorts the senses of one word as one part of speech by sense number, see
+// numberedSenses, and returns their numbers
+func numberSenses(found []Lookup) []int {
+	slices.SortStableFunc(found, func(a, b Lookup) int {
+		an, bn := a.senseNumber(), b.senseNumber()
+		switch {
+		case an == bn:
+			return 0
+		case an == 0:
+			return 1
+		case bn == 0:
+			return -1
+		}
+		return an - bn
+	})
+
+	numbers := make([]int, len(found))
+	for i := range found {
+		numbers[i] = found[i].senseNumber()
+		if numbers[i] == 0 {
+			numbers[i] = i + 1
+		}
+	}
+	return numbers
+}
+
+// the senses numbered n among found, one per part of speech
+func selectSense(found []Lookup, n int) []Lookup {
+	selected := []Lookup{}
+	for _, pos := range []PartOfSpeech{Noun, Verb, Adjective, Adverb} {
+		var senses []Lookup
+		for _, f := range found {
+			if f.cluster.pos == pos {
+				senses = append(senses, f)
+			}
+		}
+		for i, number := range numberSenses(senses) {
+			if number == n {
+				selected = append(selected, senses[i])
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// One line per sense of word as pos, in sense number order, suitable for
+// a "choose the meaning" prompt, e.g. "good (sense 1): having desirable
+// or positive qualities...".  Glosses are cut before their examples and
+// shortened to at most 80 characters.  Without the sense index
+// (index.sense) there are no sense numbers: the senses are in data file
+// order and unlabelled, e.g. "good: having desirable...".
+func (h *Handle) DescribeSenses(word string, pos PartOfSpeech) []string {
+	found, numbers, err := h.numberedSenses(word, pos)
+	if err != nil {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(found))
+	for i, f := range found {
+		label := normalize(word)
+		if h.hasFrequencies {
+			label += fmt.Sprintf(" (sense %d)", numbers[i])
+		}
+		descriptions = append(descriptions, label+": "+truncate(definition(f.Gloss()), maxDescriptionLength))
+	}
+	return descriptions
+}
+
+// The part of speech word is most often used as: the one whose senses
+// were tagged most often in total in the sense-tagged corpora or, when
+// there are no tag counts for word (e.g. without index.sense), the one
+// with the most senses.  Ties go to the earlier of Noun, Verb, Adjective
+// and Adverb.  Morphology applies as in Lookup.
+func (h *Handle) DominantPOS(word string) (PartOfSpeech, error) {
+	found, err := h.Lookup(Criteria{Matching: word})
+	if err != nil {
+		return 0, err
+	}
+	if len(found) == 0 {
+		return 0, fmt.Errorf("%w: %q", ErrWordNotFound, word)
+	}
+
+	tags := map[PartOfSpeech]int{}
+	senses := map[PartOfSpeech]int{}
+	total := 0
+	for _, f := range found {
+		tags[f.cluster.pos] += f.tagCount()
+		senses[f.cluster.pos]++
+		total += f.tagCount()
+	}
+	score := tags
+	if total == 0 {
+		score = senses
+	}
+
+	best := found[0].cluster.pos
+	for _, pos := range []PartOfSpeech{Noun, Verb, Adjective, Adverb} {
+		if score[pos] > score[best] || (score[pos] == score[best] && pos < best) {
+			best = pos
+		}
+	}
+	return best, nil
+}
+
+// The pos lemmas whose senses were tagged at least minTagCount times in
+// total in the sense-tagged corpora, most frequent first (ties in
+// alphabetical order), e.g. for a list of common words.  Without
+// frequency data (index.sense) the result is empty.
+func (h *Handle) CommonWords(pos PartOfSpeech, minTagCount int) []string {
+	if !h.hasFrequencies {
+		return nil
+	}
+	counts := h.lemmaTagCounts(PartOfSpeechList{pos})
+	maps.DeleteFunc(counts, func(_ string, count int) bool {
+		return count < minTagCount
+	})
+	return byTagCount(counts)
+}
+
+// The n lemmas tagged most often in the sense-tagged corpora, whatever
+// their part of speech, most frequent first (ties in alphabetical order),
+// e.g. for a list of common English words.  A lemma's count is that of
+// all its senses as all parts of speech.  Lemmas that were never tagged
+// are left out, so there may be fewer than n.  An error without
+// frequency data (index.sense).
+func (h *Handle) TopWords(n int) ([]string, error) {
+	if !h.hasFrequencies {
+		return nil, fmt.Errorf("no sense frequencies loaded, index.sense is needed")
+	}
+	counts := h.lemmaTagCounts(nil)
+	maps.DeleteFunc(counts, func(_ string, count int) bool {
+		return count == 0
+	})
+	words := byTagCount(counts)
+	return words[:min(max(n, 0), len(words))], nil
+}
+
+// the total tag count of the senses of each lemma having senses as any of
+// pos (all parts of speech if empty)
+func (h *Handle) lemmaTagCounts(pos PartOfSpeechList) map[string]int {
+	counts := map[string]int{}
+	for lemma, clusters := range h.index {
+		total, ok := 0, false
+		for _, c := range clusters {
+			if len(pos) > 0 && !slices.Contains(pos, c.pos) {
+				continue
+			}
+			ok = true
+			for _, m := range c.words {
+				if normalize(m.word) == lemma {
+					total += m.tagCount
+				}
+			}
+		}
+		if ok {
+			counts[lemma] = total
+		}
+	}
+	return counts
+}
+
+// the keys of counts, highest count first and in alphabetical order on
+// ties
+func byTagCount(counts map[string]int) []string {
+	words := slices.Collect(maps.Keys(counts))
+	slices.SortFunc(words, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+	return words
+}
+
+// Call fn with the sense key of every word sense, e.g. "dog%1:05:00::",
+// and the meaning it names, searched for as that member, in data file
+// order (members in synset order).  Sense keys are lower case, so
+// members differing only in case (the letter synset {A, a}) share a key,
+// which is reported once for the first of them.  Iteration stops at the
+// first error fn returns, which is passed on.
+func (h *Handle) IterateSenseKeys(fn func(key string, l Lookup) error) error {
+	for _, c := range h.db {
+		for i, m := range c.words {
+			key := c.senseKey(i)
+			if slices.ContainsFunc(c.words[:i], func(prev word) bool {
+				return prev.sense == m.sense && senseKeyLemma(prev.word) == senseKeyLemma(m.word)
+			}) {
+				continue
+			}
+			if err := fn(key, Lookup{word: m.word, cluster: c}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// The meaning a sense key such as "run%2:38:00::" names, as found in
+// SemCor and other sense tagged corpora, along with the member of its
+// synset the key refers to (e.g. "Canis familiaris" for
+// "canis_familiaris%1:05:00::").  Keys are resolved from the data files,
+// index.sense isn't needed, and are matched case insensitively.
+// Malformed keys are reported as such; well formed keys naming no sense
+// give ErrWordNotFound if the lemma isn't in the database for the key's
+// part of speech, ErrSynsetNotFound otherwise.
+func (h *Handle) LookupSenseKey(key string) (Lookup, string, error) {
+	if h.indexOnly {
+		return Lookup{}, "", ErrDataNotLoaded
+	}
+	key = strings.ToLower(key)
+	lemma, pos, err := parseSenseKey(key)
+	if err != nil {
+		return Lookup{}, "", err
+	}
+
+	known := false
+	for _, c := range h.index[normalize(lemma)] {
+		if c.pos != pos {
+			continue
+		}
+		known = true
+		for i, m := range c.words {
+			if c.senseKey(i) == key {
+				return Lookup{word: m.word, cluster: c}, m.word, nil
+			}
+		}
+	}
+	if !known {
+		return Lookup{}, "", fmt.Errorf("%w: %q (%s)", ErrWordNotFound, lemma, pos)
+	}
+	return Lookup{}, "", fmt.Errorf("%w: no sense with key %q", ErrSynsetNotFound, key)
+}
+
+// parseSenseKey checks that key has the form
+// "lemma%ss_type:lex_filenum:lex_id:head_word:head_id", where the head
+// word and id are only (and always) given for adjective satellites, and
+// returns its lemma and part of speech
+func parseSenseKey(key string) (string, PartOfSpeech, error) {
+	lemma, rest, ok := strings.Cut(key, "%")
+	if !ok || lemma == "" {
+		return "", 0, fmt.Errorf("malformed sense key %q: lemma%%lex_sense expected", key)
+	}
+	fields := strings.Split(rest, ":")
+	if len(fields) != 5 || len(fields[0]) != 1 {
+		return "", 0, fmt.Errorf("malformed sense key %q: ss_type:lex_filenum:lex_id:head_word:head_id expected after %%", key)
+	}
+	pos, err := ssTypePOS(fields[0][0])
+	if err != nil {
+		return "", 0, fmt.Errorf("malformed sense key %q: %s", key, err)
+	}
+	if !twoDigits(fields[1]) || !twoDigits(fields[2]) {
+		return "", 0, fmt.Errorf("malformed sense key %q: two digit lex_filenum and lex_id expected", key)
+	}
+	head := fields[3] != "" || fields[4] != ""
+	if satellite := fields[0] == "5"; satellite != head || satellite && (fields[3] == "" || !twoDigits(fields[4])) {
+		return "", 0, fmt.Errorf("malformed sense key %q: head_word and head_id are given for adjective satellites only", key)
+	}
+	return lemma, pos, nil
+}
+
+// whether s is a two digit decimal number
+func twoDigits(s string) bool {
+	return len(s) == 2 && '0' <= s[0] && s[0] <= '9' && '0' <= s[1] && s[1] <= '9'
+}
+
+// sorts the synsets indexed under key (in data file order) by part of
+// speech and then by the sense number of key in them, keeping those the
+// sense index doesn't number last, see Lookup
+func sortBySenseNumber(key string, clusters []*cluster) {
+	number := func(c *cluster) int {
+		for _, m := range c.words {
+			if m.senseNumber > 0 && normalize(m.word) == key {
+				return m.senseNumber
+			}
+		}
+		return math.MaxInt
+	}
+	slices.SortStableFunc(clusters, func(a, b *cluster) int {
+		if a.pos != b.pos {
+			return int(a.pos) - int(b.pos)
+		}
+		return cmp.Compare(number(a), number(b))
+	})
+}
+
+// What the index records about a lemma as one part of speech
+type WordInfo struct {
+	// The number of synsets the lemma is a member of
+	Senses int
+	// How many of those were tagged in the sense-tagged corpora
+	// (tagsense_cnt), zero without frequency data
+	TaggedSenses int
+	// How often they were tagged in total, zero without frequency data
+	// and for handles from NewIndexOnly
+	TagCount int
+}
+
+// The index figures for word as a pos lemma, matched as in Contains
+// (without morphology).  Tag counts come from index.sense; handles
+// created with NewIndexOnly take TaggedSenses from the index files and
+// have no TagCount.
+func (h *Handle) WordInfo(word string, pos PartOfSpeech) WordInfo {
+	key := h.normalizeQuery(word)
+	var info WordInfo
+	for i, c := range h.index[key] {
+		// a synset is indexed once per spelling variant of the lemma
+		if c.pos != pos || (i > 0 && h.index[key][i-1] == c) {
+			continue
+		}
+		info.Senses++
+		tags := 0
+		for _, m := range c.words {
+			if normalize(m.word) == key {
+				tags += m.tagCount
+			}
+		}
+		if tags > 0 {
+			info.TaggedSenses++
+			info.TagCount += tags
+		}
+	}
+	if h.indexOnly {
+		info.TaggedSenses = h.taggedSenses[morphKey{key, pos}]
+	}
+	return info
+}
+
+// Whether any sense of word as pos was tagged in the sense-tagged
+// corpora, i.e. whether the word occurs in them with that part of speech.
+// Always false without frequency data (see WordInfo).
+func (h *Handle) IsTagged(word string, pos PartOfSpeech) bool {
+	return h.WordInfo(word, pos).TaggedSenses > 0
+}
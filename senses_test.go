@@ -0,0 +1,308 @@
+package wnram
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// a tiny database with sense frequencies
+var frequencyFixture = map[string]string{
+	"data.noun": "00000001 06 n 03 auto 0 car 0 automobile 0 000 | a motor vehicle\n" +
+		"00000002 06 n 01 car 1 000 | a wheeled vehicle adapted to the rails of railroad\n",
+	"index.sense": "auto%1:06:00:: 00000001 2 1\n" +
+		"automobile%1:06:00:: 00000001 1 5\n" +
+		"car%1:06:00:: 00000001 1 40\n" +
+		"car%1:06:01:: 00000002 2 2\n",
+}
+
+func TestSynonymsByFrequency(t *testing.T) {
+	h, err := New(writeDataDir(t, frequencyFixture))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	found, _ := h.Lookup(Criteria{Matching: "automobile"})
+	if len(found) != 1 {
+		t.Fatalf("expected one result for automobile, got %d", len(found))
+	}
+
+	if got, want := found[0].SynonymsByFrequency(), []string{"car", "automobile", "auto"}; !slices.Equal(got, want) {
+		t.Errorf("SynonymsByFrequency() = %v; want %v", got, want)
+	}
+	if got, want := found[0].Synonyms(), []string{"auto", "car", "automobile"}; !slices.Equal(got, want) {
+		t.Errorf("Synonyms() = %v; want %v", got, want)
+	}
+}
+
+func TestSenseIndexErrors(t *testing.T) {
+	for name, index := range map[string]string{
+		"unknown synset": "car%1:06:00:: 00000009 1 40\n",
+		"unknown member": "bus%1:06:00:: 00000001 1 40\n",
+		"malformed key":  "car 00000001 1 40\n",
+		"missing fields": "car%1:06:00:: 00000001\n",
+	} {
+		_, err := New(writeDataDir(t, map[string]string{
+			"data.noun":   frequencyFixture["data.noun"],
+			"index.sense": index,
+		}))
+		if err == nil {
+			t.Errorf("%s: expected an error loading the sense index", name)
+		}
+	}
+}
+
+func TestDescribeSenses(t *testing.T) {
+	h, err := New(writeDataDir(t, frequencyFixture))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	expected := []string{
+		"car (sense 1): a motor vehicle",
+		"car (sense 2): a wheeled vehicle adapted to the rails of railroad",
+	}
+	if got := h.DescribeSenses("Car", Noun); !slices.Equal(got, expected) {
+		t.Errorf("DescribeSenses(car) = %q; want %q", got, expected)
+	}
+
+	descriptions := wnInstance.DescribeSenses("good", Adjective)
+	if len(descriptions) < 10 {
+		t.Fatalf("expected many senses of good, got %v", descriptions)
+	}
+	// no sense numbers without index.sense
+	for _, d := range descriptions {
+		if !strings.HasPrefix(d, "good: ") {
+			t.Errorf("unexpected description %q", d)
+		}
+		if strings.Contains(d, `"`) || len(d) > len("good: ")+maxDescriptionLength+3 {
+			t.Errorf("gloss not trimmed: %q", d)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in       string
+		n        int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"having desirable or positive qualities", 20, "having desirable or..."},
+		{"a, b; c d", 5, "a, b..."},
+		{"unbreakable", 4, "unbr..."},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.in, tt.n); got != tt.expected {
+			t.Errorf("truncate(%q, %d) = %q; want %q", tt.in, tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestDominantPOS(t *testing.T) {
+	for word, want := range map[string]PartOfSpeech{"run": Verb, "table": Noun, "quickly": Adverb, "tables": Noun} {
+		if got, err := wnInstance.DominantPOS(word); err != nil || got != want {
+			t.Errorf("DominantPOS(%q) = %v, %v; want %v", word, got, err, want)
+		}
+	}
+	if _, err := wnInstance.DominantPOS("xyzzyplugh"); !errors.Is(err, ErrWordNotFound) {
+		t.Errorf("expected ErrWordNotFound, got %v", err)
+	}
+
+	// tag counts outweigh the number of senses
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 05 n 01 fish 0 000 | a creature living in water\n" +
+			"00000002 13 n 01 fish 1 000 | the flesh of fish used as food\n",
+		"data.verb": "00000001 35 v 01 fish 0 000 | catch or try to catch fish\n",
+		"index.sense": "fish%1:05:00:: 00000001 1 1\n" +
+			"fish%1:13:01:: 00000002 2 1\n" +
+			"fish%2:35:00:: 00000001 1 10\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	if got, err := h.DominantPOS("fish"); err != nil || got != Verb {
+		t.Errorf("DominantPOS(fish) = %v, %v; want verb", got, err)
+	}
+}
+
+func TestCriteriaSenseNumber(t *testing.T) {
+	h, err := New(writeDataDir(t, frequencyFixture))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	for n, want := range map[int]string{1: "00000001-n", 2: "00000002-n"} {
+		found, err := h.Lookup(Criteria{Matching: "car", POS: PartOfSpeechList{Noun}, SenseNumber: n})
+		if err != nil || len(found) != 1 || found[0].SynsetID() != want {
+			t.Errorf("car#n#%d: %v, %v; want %s", n, found, err, want)
+		}
+	}
+	if found, err := h.Lookup(Criteria{Matching: "car", SenseNumber: 3}); err != nil || len(found) != 0 {
+		t.Errorf("car has no third sense, got %v, %v", found, err)
+	}
+	if _, err := h.Lookup(Criteria{Matching: "car", SenseNumber: -1}); err == nil {
+		t.Errorf("expected an error for a negative sense number")
+	}
+
+	// one sense per part of speech
+	fixture := maps.Clone(frequencyFixture)
+	fixture["data.verb"] = "00000001 38 v 01 car 0 000 | travel by car\n"
+	fixture["index.sense"] += "car%2:38:00:: 00000001 1 3\n"
+	h, err = New(writeDataDir(t, fixture))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	first, err := h.Lookup(Criteria{Matching: "car", SenseNumber: 1})
+	if err != nil || len(first) != 2 || first[0].SynsetID() != "00000001-n" || first[1].SynsetID() != "00000001-v" {
+		t.Errorf("expected the first noun and verb senses of car, got %v, %v", first, err)
+	}
+
+	// data file positions aren't sense numbers
+	if _, err := wnInstance.Lookup(Criteria{Matching: "bank", SenseNumber: 1}); err == nil {
+		t.Error("expected an error for a sense number without index.sense")
+	}
+}
+
+func TestCommonWords(t *testing.T) {
+	h, err := New(writeDataDir(t, frequencyFixture))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	if got, want := h.CommonWords(Noun, 2), []string{"car", "automobile"}; !slices.Equal(got, want) {
+		t.Errorf("CommonWords(Noun, 2) = %v; want %v", got, want)
+	}
+	if got, want := h.CommonWords(Noun, 0), []string{"car", "automobile", "auto"}; !slices.Equal(got, want) {
+		t.Errorf("CommonWords(Noun, 0) = %v; want %v", got, want)
+	}
+	if got := h.CommonWords(Verb, 0); len(got) != 0 {
+		t.Errorf("expected no common verbs, got %v", got)
+	}
+	if got := wnInstance.CommonWords(Noun, 0); len(got) != 0 {
+		t.Errorf("expected no common words without frequency data, got %d", len(got))
+	}
+}
+
+func TestTopWords(t *testing.T) {
+	fixture := maps.Clone(frequencyFixture)
+	fixture["data.verb"] = "00000001 38 v 02 auto 0 motor 0 000 | travel in an automobile\n"
+	fixture["index.sense"] = "auto%1:06:00:: 00000001 2 1\n" +
+		"auto%2:38:00:: 00000001 1 10\n" +
+		"automobile%1:06:00:: 00000001 1 5\n" +
+		"car%1:06:00:: 00000001 1 40\n" +
+		"car%1:06:01:: 00000002 2 2\n"
+	h, err := New(writeDataDir(t, fixture))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	// auto is counted as noun and verb, motor never was tagged
+	for n, want := range map[int][]string{10: {"car", "auto", "automobile"}, 2: {"car", "auto"}, 0: {}} {
+		if got, err := h.TopWords(n); err != nil || !slices.Equal(got, want) {
+			t.Errorf("TopWords(%d) = %v, %v; want %v", n, got, err, want)
+		}
+	}
+	if _, err := wnInstance.TopWords(10); err == nil {
+		t.Error("expected an error without frequency data")
+	}
+}
+
+func TestIterateSenseKeys(t *testing.T) {
+	keys := map[string]Lookup{}
+	senses := 0
+	err := wnInstance.IterateSenseKeys(func(key string, l Lookup) error {
+		if _, dup := keys[key]; dup {
+			return fmt.Errorf("duplicate sense key %s", key)
+		}
+		keys[key] = l
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wnInstance.Iterate(nil, func(l Lookup) error {
+		lemmas := map[string]bool{}
+		for _, m := range l.cluster.words {
+			lemmas[fmt.Sprintf("%s:%02d", senseKeyLemma(m.word), m.sense)] = true
+		}
+		senses += len(lemmas)
+		return nil
+	})
+	if len(keys) != senses {
+		t.Errorf("got %d sense keys for %d word senses", len(keys), senses)
+	}
+
+	dog := specificSense(t, "dog", Noun, "domesticated")
+	if l, ok := keys["dog%1:05:00::"]; !ok || l.cluster != dog.cluster || l.Word() != "dog" {
+		t.Errorf("dog%%1:05:00:: names %s, want %s", l.Canonical(), dog.Canonical())
+	}
+
+	// each key leads back to its sense
+	for key, l := range keys {
+		if found, lemma, err := wnInstance.LookupSenseKey(key); err != nil || found.cluster != l.cluster || lemma != l.Word() {
+			t.Fatalf("LookupSenseKey(%s) = %s, %q, %v; want %s", key, found.Canonical(), lemma, err, l.Canonical())
+		}
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = wnInstance.IterateSenseKeys(func(string, Lookup) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("expected iteration to stop at the first error, got %v after %d calls", err, calls)
+	}
+}
+
+func TestWordInfo(t *testing.T) {
+	h, err := New(writeDataDir(t, frequencyFixture))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	if got, want := h.WordInfo("Car", Noun), (WordInfo{Senses: 2, TaggedSenses: 2, TagCount: 42}); got != want {
+		t.Errorf("WordInfo(car) = %+v; want %+v", got, want)
+	}
+	if !h.IsTagged("auto", Noun) || h.IsTagged("auto", Verb) || h.IsTagged("bus", Noun) {
+		t.Error("unexpected IsTagged results")
+	}
+
+	if got, want := wnInstance.WordInfo("dog", Noun), (WordInfo{Senses: 7}); got != want {
+		t.Errorf("WordInfo(dog) without frequencies = %+v; want %+v", got, want)
+	}
+	if wnInstance.IsTagged("dog", Noun) {
+		t.Error("no word is tagged without frequency data")
+	}
+}
+
+func TestLookupSenseKey(t *testing.T) {
+	for key, want := range map[string]struct{ lemma, gloss string }{
+		"run%2:38:00::":              {"run", "move fast by using one's feet"},
+		"Canis_Familiaris%1:05:00::": {"Canis familiaris", "a member of the genus Canis"},
+		"ambrosial%5:00:00:tasty:00": {"ambrosial", "extremely pleasing to the taste"},
+	} {
+		l, lemma, err := wnInstance.LookupSenseKey(key)
+		if err != nil {
+			t.Errorf("LookupSenseKey(%s): %s", key, err)
+			continue
+		}
+		if lemma != want.lemma || l.Word() != want.lemma || !strings.HasPrefix(l.Gloss(), want.gloss) {
+			t.Errorf("LookupSenseKey(%s) = %q %q; want %q %q", key, lemma, l.Gloss(), want.lemma, want.gloss)
+		}
+	}
+
+	for _, key := range []string{"", "dog", "%1:05:00::", "dog%", "dog%1:05:00:", "dog%6:05:00::", "dog%1:5:00::", "dog%1:05:0x::", "dog%1:05:00:canine:01", "tasty%5:00:00::"} {
+		if _, _, err := wnInstance.LookupSenseKey(key); err == nil || !strings.Contains(err.Error(), "malformed sense key") {
+			t.Errorf("expected %q to be malformed, got %v", key, err)
+		}
+	}
+	if _, _, err := wnInstance.LookupSenseKey("dog%1:05:99::"); !errors.Is(err, ErrSynsetNotFound) {
+		t.Errorf("expected ErrSynsetNotFound, got %v", err)
+	}
+	if _, _, err := wnInstance.LookupSenseKey("xyzzyplugh%1:05:00::"); !errors.Is(err, ErrWordNotFound) {
+		t.Errorf("expected ErrWordNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package wnram
+
+import "fmt"
+
+// whether c is at the top of its hypernym hierarchy
+func (c *cluster) isRoot() bool {
+	for _, rel := range c.relations {
+		if rel.rel&generalizations != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// the number of hypernym links on the shortest path from c to a root
+func (c *cluster) minDepth() int {
+	depth := -1
+	for a, d := range ancestors(c) {
+		if a.isRoot() && (depth < 0 || d < depth) {
+			depth = d
+		}
+	}
+	if depth < 0 {
+		// only possible with a hypernym cycle, treat c as a root
+		return 0
+	}
+	return depth
+}
+
+// The number of hypernym links on the shortest path from this synset to
+// the root of its hierarchy, zero for roots and for adjectives and
+// adverbs, which have no hypernyms, and for the zero Lookup.
+func (w *Lookup) Depth() int {
+	return w.synset().minDepth()
+}
+
+// the depth of c counted in nodes (roots are at depth one), including
+// the virtual verb root when enabled
+func (h *Handle) nodeDepth(c *cluster) int {
+	d := h.depth(c) + 1
+	if c.pos == Verb && h.opts.VirtualVerbRoot {
+		d++
+	}
+	return d
+}
+
+// checks that a and b can be compared by a hierarchy based measure
+func checkComparable(a, b Lookup) error {
+	if a.cluster == nil || b.cluster == nil {
+		return fmt.Errorf("can't compare the zero Lookup")
+	}
+	if a.cluster.pos != b.cluster.pos {
+		return fmt.Errorf("can't compare a %s with a %s", a.cluster.pos, b.cluster.pos)
+	}
+	if a.cluster.pos != Noun && a.cluster.pos != Verb {
+		return fmt.Errorf("%ss have no hypernym hierarchy", a.cluster.pos)
+	}
+	return nil
+}
+
+// The Wu & Palmer similarity of two synsets, 2*depth(lcs) / (depth(a) +
+// depth(b)), where lcs is the deepest common hypernym and depths count
+// nodes from the root.  With multiple inheritance every hypernym path is
+// considered, the depths of a and b being measured along the path through
+// the lcs, and the best scoring common hypernym is used.  The result is
+// in (0, 1], 1 meaning the same synset.  Both synsets must be nouns or
+// both verbs.
+//
+// Verbs form many separate hierarchies.  By default two verbs without a
+// common hypernym can't be compared and an error is returned.  With
+// Options.VirtualVerbRoot all verb roots are treated as children of one
+// virtual root, which then serves as their common hypernym; this also
+// makes every verb one level deeper, so verb scores differ slightly from
+// those computed without it.
+func (h *Handle) WuPalmerSimilarity(a, b Lookup) (float64, error) {
+	if err := checkComparable(a, b); err != nil {
+		return 0, err
+	}
+
+	upA, upB := ancestors(a.cluster), ancestors(b.cluster)
+	best := -1.0
+	for c, da := range upA {
+		db, ok := upB[c]
+		if !ok {
+			continue
+		}
+		depth := float64(h.nodeDepth(c))
+		if score := 2 * depth / (float64(da+db) + 2*depth); score > best {
+			best = score
+		}
+	}
+
+	if best < 0 {
+		if a.cluster.pos != Verb || !h.opts.VirtualVerbRoot {
+			return 0, fmt.Errorf("%s and %s share no hypernym", a.cluster.id(), b.cluster.id())
+		}
+		// the virtual root is the only common hypernym
+		da, db := h.nodeDepth(a.cluster)-1, h.nodeDepth(b.cluster)-1
+		best = 2 / float64(da+db+2)
+	}
+
+	return best, nil
+}
+
+// The path similarity of two synsets, 1 / (1 + the number of links on the
+// shortest path between them through a common hypernym).  Every hypernym
+// path of both synsets is considered (nouns may have several parents).
+// The result is in (0, 1], 1 meaning the same synset.  The same
+// restrictions as for WuPalmerSimilarity apply, including the treatment
+// of verbs without a common hypernym.
+func (h *Handle) PathSimilarity(a, b Lookup) (float64, error) {
+	if err := checkComparable(a, b); err != nil {
+		return 0, err
+	}
+
+	upA, upB := ancestors(a.cluster), ancestors(b.cluster)
+	shortest := -1
+	for c, da := range upA {
+		if db, ok := upB[c]; ok && (shortest < 0 || da+db < shortest) {
+			shortest = da + db
+		}
+	}
+
+	if shortest < 0 {
+		if a.cluster.pos != Verb || !h.opts.VirtualVerbRoot {
+			return 0, fmt.Errorf("%s and %s share no hypernym", a.cluster.id(), b.cluster.id())
+		}
+		// up to the virtual root and back down
+		shortest = h.depth(a.cluster) + 1 + h.depth(b.cluster) + 1
+	}
+
+	return 1 / float64(shortest+1), nil
+}
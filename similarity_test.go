@@ -0,0 +1,139 @@
+package wnram
+
+import "testing"
+
+// the first sense of word as pos
+func firstSense(t *testing.T, h *Handle, word string, pos PartOfSpeech) Lookup {
+	t.Helper()
+	found, err := h.Lookup(Criteria{Matching: word, POS: []PartOfSpeech{pos}})
+	if err != nil || len(found) == 0 {
+		t.Fatalf("can't find %s %q: %v", pos, word, err)
+	}
+	return found[0]
+}
+
+// the sense of word as pos whose gloss mentions keyword
+func specificSense(t *testing.T, word string, pos PartOfSpeech, keyword string) Lookup {
+	t.Helper()
+	l, err := wnInstance.LookupSpecific(word, pos, keyword)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return l
+}
+
+func TestDepth(t *testing.T) {
+	entity := wnInstance.Roots(Noun)[0]
+	if d := entity.Depth(); d != 0 {
+		t.Errorf("depth of entity = %d; want 0", d)
+	}
+	dog := firstSense(t, wnInstance, "dog", Noun)
+	if d := dog.Depth(); d < 5 || d > 15 {
+		t.Errorf("implausible depth for dog: %d", d)
+	}
+	good := firstSense(t, wnInstance, "good", Adjective)
+	if d := good.Depth(); d != 0 {
+		t.Errorf("adjectives should have depth 0, got %d", d)
+	}
+}
+
+func TestWuPalmerSimilarity(t *testing.T) {
+	dog := specificSense(t, "dog", Noun, "domesticated")
+	cat := specificSense(t, "cat", Noun, "feline mammal")
+	car := specificSense(t, "car", Noun, "motor vehicle")
+
+	same, err := wnInstance.WuPalmerSimilarity(dog, dog)
+	if err != nil || same != 1 {
+		t.Errorf("similarity of dog with itself = %v, %v", same, err)
+	}
+	dogCat, err := wnInstance.WuPalmerSimilarity(dog, cat)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	dogCar, err := wnInstance.WuPalmerSimilarity(dog, car)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if dogCat < 0.8 || dogCat <= dogCar {
+		t.Errorf("expected dog to be much closer to cat (%v) than to car (%v)", dogCat, dogCar)
+	}
+	if catDog, _ := wnInstance.WuPalmerSimilarity(cat, dog); catDog != dogCat {
+		t.Errorf("similarity isn't symmetric: %v != %v", catDog, dogCat)
+	}
+
+	if _, err := wnInstance.WuPalmerSimilarity(dog, firstSense(t, wnInstance, "run", Verb)); err == nil {
+		t.Errorf("expected an error comparing a noun with a verb")
+	}
+	if _, err := wnInstance.WuPalmerSimilarity(firstSense(t, wnInstance, "good", Adjective), firstSense(t, wnInstance, "bad", Adjective)); err == nil {
+		t.Errorf("expected an error comparing adjectives")
+	}
+}
+
+func TestPathSimilarity(t *testing.T) {
+	dog := specificSense(t, "dog", Noun, "domesticated")
+	cat := specificSense(t, "cat", Noun, "feline mammal")
+	car := specificSense(t, "car", Noun, "motor vehicle")
+
+	if same, err := wnInstance.PathSimilarity(dog, dog); err != nil || same != 1 {
+		t.Errorf("similarity of dog with itself = %v, %v", same, err)
+	}
+	dogCat, _ := wnInstance.PathSimilarity(dog, cat)
+	dogCar, _ := wnInstance.PathSimilarity(dog, car)
+	if dogCat <= dogCar || dogCat > 0.5 {
+		t.Errorf("unexpected path similarities dog/cat %v, dog/car %v", dogCat, dogCar)
+	}
+	if _, err := wnInstance.PathSimilarity(dog, firstSense(t, wnInstance, "run", Verb)); err == nil {
+		t.Errorf("expected an error comparing a noun with a verb")
+	}
+}
+
+// person has two hypernyms, organism and causal agent, which are at
+// different depths.  Both are its parents whichever path is found first.
+func TestMultipleInheritanceSimilarity(t *testing.T) {
+	person := specificSense(t, "person", Noun, "a human being")
+	for _, parent := range []Lookup{
+		specificSense(t, "organism", Noun, "living thing"),
+		specificSense(t, "causal agent", Noun, "produces an effect"),
+	} {
+		if score, err := wnInstance.PathSimilarity(person, parent); err != nil || score != 0.5 {
+			t.Errorf("path similarity of person and its parent %s = %v, %v; want 0.5", parent.Lemma(), score, err)
+		}
+
+		// the lcs is the parent itself, one node above person
+		d := float64(parent.Depth() + 1)
+		if score, err := wnInstance.WuPalmerSimilarity(person, parent); err != nil || score != 2*d/(2*d+1) {
+			t.Errorf("Wu-Palmer similarity of person and %s = %v, %v; want %v", parent.Lemma(), score, err, 2*d/(2*d+1))
+		}
+	}
+}
+
+func TestVirtualVerbRoot(t *testing.T) {
+	breathe, err := wnInstance.LookupByOffset(Verb, 1740)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	think := firstSense(t, wnInstance, "think", Verb)
+	if _, err := wnInstance.WuPalmerSimilarity(breathe, think); err == nil {
+		t.Fatalf("expected an error comparing verbs from separate hierarchies")
+	}
+
+	h, err := NewWithOptions(sourceCodeRelPath(PathToWordnetDataFiles), Options{VirtualVerbRoot: true})
+	if err != nil {
+		t.Fatalf("can't load: %s", err)
+	}
+	breathe, _ = h.LookupByOffset(Verb, 1740)
+	think = firstSense(t, h, "think", Verb)
+	score, err := h.WuPalmerSimilarity(breathe, think)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if score <= 0 || score >= 0.5 {
+		t.Errorf("unexpected similarity through the virtual root: %v", score)
+	}
+	if same, _ := h.WuPalmerSimilarity(think, think); same != 1 {
+		t.Errorf("similarity of think with itself = %v", same)
+	}
+	if path, err := h.PathSimilarity(breathe, think); err != nil || path <= 0 || path >= 0.5 {
+		t.Errorf("unexpected path similarity through the virtual root: %v, %v", path, err)
+	}
+}
@@ -0,0 +1,82 @@
+package wnram
+
+import "maps"
+
+// Descriptive statistics about a loaded database and its caches
+type Stats struct {
+	Synsets int // number of synsets loaded
+	Words   int // number of distinct (normalized) words indexed
+	// synsets left out for having no members, see New
+	EmptySynsets int
+
+	// The largest number of synsets any one word belongs to, by part of
+	// speech
+	MaxPolysemy map[PartOfSpeech]int
+	// The average number of synsets per word, over the words having at
+	// least one synset of that part of speech
+	AvgPolysemy map[PartOfSpeech]float64
+	// A word with MaxPolysemy synsets (the alphabetically first on ties)
+	MostPolysemous map[PartOfSpeech]string
+
+	// MorphWord cache activity, both zero without Options.MorphCacheSize
+	MorphCacheHits   uint64
+	MorphCacheMisses uint64
+	// Lookup cache activity, both zero without Options.LookupCacheSize
+	LookupCacheHits   uint64
+	LookupCacheMisses uint64
+}
+
+// polysemy figures, counted once at load time
+type polysemy struct {
+	max  map[PartOfSpeech]int
+	avg  map[PartOfSpeech]float64
+	most map[PartOfSpeech]string
+}
+
+func countPolysemy(index map[string][]*cluster) polysemy {
+	p := polysemy{
+		max:  map[PartOfSpeech]int{},
+		avg:  map[PartOfSpeech]float64{},
+		most: map[PartOfSpeech]string{},
+	}
+	senses := map[PartOfSpeech]int{}
+	words := map[PartOfSpeech]int{}
+	for word, clusters := range index {
+		count := map[PartOfSpeech]int{}
+		for i, c := range clusters {
+			// a synset lists a word once per spelling variant ("Mercury",
+			// "mercury"), those entries are adjacent
+			if i > 0 && clusters[i-1] == c {
+				continue
+			}
+			count[c.pos]++
+		}
+		for pos, n := range count {
+			senses[pos] += n
+			words[pos]++
+			if n > p.max[pos] || (n == p.max[pos] && word < p.most[pos]) {
+				p.max[pos] = n
+				p.most[pos] = word
+			}
+		}
+	}
+	for pos, n := range words {
+		p.avg[pos] = float64(senses[pos]) / float64(n)
+	}
+	return p
+}
+
+// Statistics about this handle
+func (h *Handle) Stats() Stats {
+	s := Stats{
+		Synsets:        len(h.db),
+		Words:          len(h.index),
+		EmptySynsets:   h.emptySynsets,
+		MaxPolysemy:    maps.Clone(h.polysemy.max),
+		AvgPolysemy:    maps.Clone(h.polysemy.avg),
+		MostPolysemous: maps.Clone(h.polysemy.most),
+	}
+	s.MorphCacheHits, s.MorphCacheMisses = h.morphCache.stats()
+	s.LookupCacheHits, s.LookupCacheMisses = h.lookupCache.stats()
+	return s
+}
@@ -0,0 +1,36 @@
+package wnram
+
+import "testing"
+
+func TestPolysemyStats(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 02 bank 0 depository 0 000 | a financial institution\n" +
+			"00000002 03 n 01 bank 1 000 | sloping land beside a river\n" +
+			"00000003 03 n 02 Mercury 0 mercury 0 000 | a planet\n" +
+			"00000004 03 n 01 cant 0 000 | a slope\n",
+		"data.verb": "00000001 40 v 01 bank 0 000 | do business with a bank\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	s := h.Stats()
+	if s.MaxPolysemy[Noun] != 2 || s.MostPolysemous[Noun] != "bank" {
+		t.Errorf("noun polysemy: max %d (%q), want 2 (bank)", s.MaxPolysemy[Noun], s.MostPolysemous[Noun])
+	}
+	// bank 2, depository, mercury and cant 1 each
+	if s.AvgPolysemy[Noun] != 5.0/4 {
+		t.Errorf("average noun polysemy %v, want 1.25", s.AvgPolysemy[Noun])
+	}
+	if s.MaxPolysemy[Verb] != 1 || s.MostPolysemous[Verb] != "bank" || s.AvgPolysemy[Verb] != 1 {
+		t.Errorf("unexpected verb polysemy %d %q %v", s.MaxPolysemy[Verb], s.MostPolysemous[Verb], s.AvgPolysemy[Verb])
+	}
+	if _, ok := s.MaxPolysemy[Adverb]; ok {
+		t.Errorf("no adverbs loaded, got %d", s.MaxPolysemy[Adverb])
+	}
+
+	s.MaxPolysemy[Noun] = 100
+	if h.Stats().MaxPolysemy[Noun] != 2 {
+		t.Errorf("Stats shares its maps with the handle")
+	}
+}
@@ -0,0 +1,128 @@
+package wnram
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// the most corrections LookupOrSuggest offers
+const maxSuggestions = 10
+
+// The meanings of word as pos, for a search box: tries the word itself,
+// then its base form (see MorphWord; Matched reports the form that was
+// found), and if neither is in the database returns corrections instead.
+// Suggestions are pos lemmas within a small edit distance of word (one
+// edit for words of up to four letters, two otherwise, counting
+// transpositions as one), closest first, then the more polysemous, then
+// alphabetically.  At most one of results and suggestions is non-empty.
+// The error is ErrEmptyQuery for an empty word, ErrDataNotLoaded for
+// handles created with NewIndexOnly, nil otherwise.
+func (h *Handle) LookupOrSuggest(word string, pos PartOfSpeech) (results []Lookup, suggestions []string, err error) {
+	if h.indexOnly {
+		return nil, nil, ErrDataNotLoaded
+	}
+	if strings.TrimSpace(word) == "" {
+		return nil, nil, ErrEmptyQuery
+	}
+
+	if results = h.lookup(word, PartOfSpeechList{pos}); len(results) > 0 {
+		return results, nil, nil
+	}
+	query := h.normalizeQuery(word)
+	if base := h.MorphWord(query, pos); base != "" {
+		if results = h.lookup(base, PartOfSpeechList{pos}); len(results) > 0 {
+			for i := range results {
+				results[i].word = word
+				results[i].matched = base
+			}
+			return results, nil, nil
+		}
+	}
+
+	return nil, h.suggest(query, pos), nil
+}
+
+// pos lemmas close to query, best first
+func (h *Handle) suggest(query string, pos PartOfSpeech) []string {
+	maxDist := 2
+	if utf8.RuneCountInString(query) <= 4 {
+		maxDist = 1
+	}
+
+	type candidate struct {
+		lemma    string
+		dist     int
+		polysemy int
+	}
+	found := []candidate{}
+	q := []rune(query)
+	for lemma, clusters := range h.index {
+		n := 0
+		for _, c := range clusters {
+			if c.pos == pos {
+				n++
+			}
+		}
+		if n == 0 {
+			continue
+		}
+		if d := editDistance(q, []rune(lemma), maxDist); d <= maxDist {
+			found = append(found, candidate{lemma, d, n})
+		}
+	}
+
+	slices.SortFunc(found, func(a, b candidate) int {
+		if a.dist != b.dist {
+			return a.dist - b.dist
+		}
+		if a.polysemy != b.polysemy {
+			return b.polysemy - a.polysemy
+		}
+		return cmp.Compare(a.lemma, b.lemma)
+	})
+
+	suggestions := []string{}
+	for _, c := range found[:min(len(found), maxSuggestions)] {
+		suggestions = append(suggestions, c.lemma)
+	}
+	return suggestions
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// the Levenshtein distance with adjacent transpositions counting as a
+// single edit.  Any distance over limit is reported as limit+1.
+func editDistance(a, b []rune, limit int) int {
+	if abs := len(a) - len(b); abs > limit || -abs > limit {
+		return limit + 1
+	}
+
+	// three rows of the dynamic programming matrix
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			best = min(best, cur[j])
+		}
+		if best > limit {
+			return limit + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return min(prev[len(b)], limit+1)
+}
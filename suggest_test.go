@@ -0,0 +1,66 @@
+package wnram
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLookupOrSuggest(t *testing.T) {
+	results, suggestions, err := wnInstance.LookupOrSuggest("dog", Noun)
+	if err != nil || len(results) == 0 || suggestions != nil {
+		t.Errorf("dog: %d results, suggestions %v, err %v", len(results), suggestions, err)
+	}
+
+	results, suggestions, err = wnInstance.LookupOrSuggest("geese", Noun)
+	if err != nil || len(results) == 0 || suggestions != nil {
+		t.Fatalf("geese: %d results, suggestions %v, err %v", len(results), suggestions, err)
+	}
+	if m := results[0].Matched(); m != "goose" {
+		t.Errorf("geese matched as %q, want goose", m)
+	}
+
+	results, suggestions, err = wnInstance.LookupOrSuggest("elephnat", Noun)
+	if err != nil || len(results) != 0 {
+		t.Fatalf("elephnat: %d results, err %v", len(results), err)
+	}
+	if len(suggestions) == 0 || suggestions[0] != "elephant" {
+		t.Errorf("expected elephant first, got %v", suggestions)
+	}
+	if len(suggestions) > maxSuggestions {
+		t.Errorf("too many suggestions: %d", len(suggestions))
+	}
+
+	// short words only get single edits
+	_, suggestions, _ = wnInstance.LookupOrSuggest("dgo", Noun)
+	if !slices.Contains(suggestions, "dog") {
+		t.Errorf("expected dog among %v", suggestions)
+	}
+	_, suggestions, _ = wnInstance.LookupOrSuggest("xqzv", Adverb)
+	if len(suggestions) != 0 {
+		t.Errorf("no adverb is one edit from xqzv, got %v", suggestions)
+	}
+
+	if _, _, err := wnInstance.LookupOrSuggest("  ", Noun); !errors.Is(err, ErrEmptyQuery) {
+		t.Errorf("expected ErrEmptyQuery, got %v", err)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, tt := range []struct {
+		a, b     string
+		limit    int
+		expected int
+	}{
+		{"kitten", "sitting", 5, 3},
+		{"kitten", "sitting", 2, 3},
+		{"elephnat", "elephant", 2, 1},
+		{"", "abc", 5, 3},
+		{"abc", "abc", 0, 0},
+		{"café", "cafe", 2, 1},
+	} {
+		if got := editDistance([]rune(tt.a), []rune(tt.b), tt.limit); got != tt.expected {
+			t.Errorf("editDistance(%q, %q, %d) = %d; want %d", tt.a, tt.b, tt.limit, got, tt.expected)
+		}
+	}
+}
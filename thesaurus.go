@@ -0,0 +1,140 @@
+package wnram
+
+import (
+	"maps"
+	"slices"
+)
+
+// One meaning of a word as presented by a thesaurus
+type SenseEntry struct {
+	Gloss    string
+	Synonyms []string // other members of the synset, without the word itself
+	Antonyms []string
+}
+
+// how often the searched word was tagged with this meaning, zero if
+// unknown
+func (w *Lookup) tagCount() int {
+	if m := w.member(); m != nil {
+		return m.tagCount
+	}
+	return 0
+}
+
+// Everything a thesaurus shows for word as pos: each meaning (most
+// frequent first when frequency data is loaded) with its gloss, synonyms
+// and antonyms.
+func (h *Handle) Thesaurus(word string, pos PartOfSpeech) []SenseEntry {
+	found, err := h.sensesByFrequency(word, PartOfSpeechList{pos})
+	if err != nil {
+		return nil
+	}
+
+	entries := make([]SenseEntry, 0, len(found))
+	for _, f := range found {
+		e := SenseEntry{Gloss: f.Gloss()}
+		self := f.member()
+		for i := range f.cluster.words {
+			if &f.cluster.words[i] != self {
+				e.Synonyms = append(e.Synonyms, f.cluster.words[i].word)
+			}
+		}
+		for _, a := range f.Related(Antonym) {
+			if !slices.Contains(e.Antonyms, a.Word()) {
+				e.Antonyms = append(e.Antonyms, a.Word())
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// Up to n replacement candidates for word as pos (all of them if n <= 0),
+// for "use a different word" suggestions.  Candidates are drawn from
+// three sources, which rank in this order: the other members of word's
+// synsets, the members of similar adjective synsets (SimilarTo) and the
+// coordinate terms of nouns and verbs (the other hyponyms of their
+// hypernyms).  Within a source the candidates most often tagged in the
+// sense-tagged corpora come first, then those from word's more frequent
+// senses.  Word itself, the lemma it was found as and inflections of
+// either are left out.
+func (h *Handle) Alternatives(word string, pos PartOfSpeech, n int) []string {
+	found, err := h.sensesByFrequency(word, PartOfSpeechList{pos})
+	if err != nil || len(found) == 0 {
+		return nil
+	}
+
+	excluded := map[string]bool{h.normalizeQuery(word): true}
+	if m := found[0].Matched(); m != "" {
+		excluded[m] = true
+	}
+	isInput := func(w string) bool {
+		w = normalize(w)
+		return excluded[w] || excluded[h.MorphWord(w, pos)]
+	}
+
+	type candidate struct {
+		word     string
+		source   int
+		tagCount int
+		order    int
+	}
+	best := map[string]candidate{}
+	add := func(c *cluster, source int) {
+		for _, m := range c.words {
+			if isInput(m.word) {
+				continue
+			}
+			cand := candidate{m.word, source, m.tagCount, len(best)}
+			if prev, ok := best[m.word]; ok {
+				if prev.source < source || (prev.source == source && prev.tagCount >= m.tagCount) {
+					continue
+				}
+				cand.order = prev.order
+			}
+			best[m.word] = cand
+		}
+	}
+
+	for _, f := range found {
+		add(f.cluster, 0)
+	}
+	for _, f := range found {
+		for _, rel := range f.cluster.relations {
+			if rel.rel == SimilarTo {
+				add(rel.target, 1)
+			}
+		}
+	}
+	for _, f := range found {
+		for _, up := range f.cluster.relations {
+			if up.rel&generalizations == 0 {
+				continue
+			}
+			for _, down := range up.target.relations {
+				if down.rel&(Hyponym|InstanceHyponym) != 0 && down.target != f.cluster {
+					add(down.target, 2)
+				}
+			}
+		}
+	}
+
+	ranked := slices.SortedFunc(maps.Values(best), func(a, b candidate) int {
+		if a.source != b.source {
+			return a.source - b.source
+		}
+		if a.tagCount != b.tagCount {
+			return b.tagCount - a.tagCount
+		}
+		return a.order - b.order
+	})
+	if n > 0 && len(ranked) > n {
+		ranked = ranked[:n]
+	}
+
+	words := make([]string, len(ranked))
+	for i, c := range ranked {
+		words[i] = c.word
+	}
+	return words
+}
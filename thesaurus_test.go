@@ -0,0 +1,84 @@
+package wnram
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestThesaurus(t *testing.T) {
+	entries := wnInstance.Thesaurus("good", Adjective)
+	if len(entries) < 10 {
+		t.Fatalf("expected many senses of good, got %d", len(entries))
+	}
+
+	var antonyms []string
+	for _, e := range entries {
+		if e.Gloss == "" {
+			t.Errorf("sense without gloss: %+v", e)
+		}
+		if slices.Contains(e.Synonyms, "good") {
+			t.Errorf("good listed as its own synonym: %+v", e)
+		}
+		antonyms = append(antonyms, e.Antonyms...)
+	}
+	if !setContains(antonyms, []string{"bad", "evil"}) {
+		t.Errorf("missing antonyms for good, got %v", antonyms)
+	}
+}
+
+func TestThesaurusFrequencyOrder(t *testing.T) {
+	// the more frequent sense of car last in the data file
+	fixture := maps.Clone(frequencyFixture)
+	fixture["data.noun"] = "00000002 06 n 01 car 1 000 | a wheeled vehicle adapted to the rails of railroad\n" +
+		"00000001 06 n 03 auto 0 car 0 automobile 0 000 | a motor vehicle\n"
+	h, err := New(writeDataDir(t, fixture))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	entries := h.Thesaurus("car", Noun)
+	if len(entries) != 2 {
+		t.Fatalf("expected two senses of car, got %d", len(entries))
+	}
+	if entries[0].Gloss != "a motor vehicle" || !slices.Equal(entries[0].Synonyms, []string{"auto", "automobile"}) {
+		t.Errorf("expected the most frequent sense first, got %+v", entries[0])
+	}
+	if len(entries[1].Synonyms) != 0 {
+		t.Errorf("expected no synonyms for the railway car, got %v", entries[1].Synonyms)
+	}
+}
+
+func TestAlternatives(t *testing.T) {
+	alts := wnInstance.Alternatives("dogs", Noun, 0)
+	if slices.Contains(alts, "dog") || slices.Contains(alts, "dogs") {
+		t.Errorf("the input word shouldn't be suggested: %v", alts)
+	}
+	if !setContains(alts, []string{"domestic dog", "wolf"}) {
+		t.Errorf("expected synonyms and coordinate terms of dog, got %v", alts)
+	}
+	if slices.Index(alts, "domestic dog") > slices.Index(alts, "wolf") {
+		t.Errorf("synonyms should rank before coordinate terms: %v", alts)
+	}
+	if got := wnInstance.Alternatives("dog", Noun, 3); len(got) != 3 || !slices.Equal(got, alts[:3]) {
+		t.Errorf("Alternatives(dog, 3) = %v; want %v", got, alts[:3])
+	}
+
+	// similar adjectives
+	if got := wnInstance.Alternatives("yummy", Adjective, 0); !setContains(got, []string{"delicious", "tasty"}) {
+		t.Errorf("expected synonyms and the head of yummy, got %v", got)
+	}
+
+	// the most frequent synonym first
+	h, err := New(writeDataDir(t, frequencyFixture))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	if got := h.Alternatives("auto", Noun, 0); !slices.Equal(got, []string{"car", "automobile"}) {
+		t.Errorf("Alternatives(auto) = %v; want [car automobile]", got)
+	}
+
+	if got := wnInstance.Alternatives("xyzzyplugh", Noun, 5); got != nil {
+		t.Errorf("expected nothing for an unknown word, got %v", got)
+	}
+}
@@ -0,0 +1,76 @@
+package wnram
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// the most synsets PrintTree will show
+const maxTreeNodes = 500
+
+// Render the synsets reachable from root through r as an indented tree in
+// the style of the tree command, each with its members and a shortened
+// gloss, e.g. the hyponyms of "dog" with r = Hyponym.  At most maxDepth
+// levels below root are shown (no limit if maxDepth <= 0), and at most
+// 500 synsets in total.  A synset reachable along several paths is only
+// expanded the first time it is printed.  Nothing is printed for the zero
+// Lookup.
+func (h *Handle) PrintTree(w io.Writer, root Lookup, r Relation, maxDepth int) {
+	if root.cluster == nil {
+		return
+	}
+	t := treePrinter{out: w, rel: r, maxDepth: maxDepth, seen: map[*cluster]bool{}}
+	t.print(root.cluster, "", "", 0)
+	if t.truncated {
+		fmt.Fprintf(w, "... (truncated at %d synsets)\n", maxTreeNodes)
+	}
+}
+
+type treePrinter struct {
+	out       io.Writer
+	rel       Relation
+	maxDepth  int
+	seen      map[*cluster]bool
+	printed   int
+	truncated bool
+}
+
+func (t *treePrinter) print(c *cluster, prefix, childPrefix string, depth int) {
+	if t.printed >= maxTreeNodes {
+		t.truncated = true
+		return
+	}
+	t.printed++
+
+	words := make([]string, 0, len(c.words))
+	for _, w := range c.words {
+		words = append(words, w.word)
+	}
+	label := fmt.Sprintf("%s: %s", strings.Join(words, ", "), truncate(definition(c.gloss), 60))
+
+	if t.seen[c] {
+		fmt.Fprintf(t.out, "%s%s (see above)\n", prefix, label)
+		return
+	}
+	t.seen[c] = true
+	fmt.Fprintf(t.out, "%s%s\n", prefix, label)
+
+	if t.maxDepth > 0 && depth >= t.maxDepth {
+		return
+	}
+
+	var children []*cluster
+	for _, rel := range c.relations {
+		if rel.rel&t.rel != 0 {
+			children = append(children, rel.target)
+		}
+	}
+	for i, child := range children {
+		if i == len(children)-1 {
+			t.print(child, childPrefix+"└── ", childPrefix+"    ", depth+1)
+		} else {
+			t.print(child, childPrefix+"├── ", childPrefix+"│   ", depth+1)
+		}
+	}
+}
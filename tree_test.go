@@ -0,0 +1,67 @@
+package wnram
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintTree(t *testing.T) {
+	dog := specificSense(t, "dog", Noun, "domesticated")
+
+	var buf bytes.Buffer
+	wnInstance.PrintTree(&buf, dog, Hyponym, 1)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if !strings.HasPrefix(lines[0], "dog, domestic dog, Canis familiaris: ") {
+		t.Errorf("unexpected root line %q", lines[0])
+	}
+	if len(lines) != dog.RelationCount(Hyponym)+1 {
+		t.Errorf("expected one line per direct hyponym, got:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "├── puppy: ") || !strings.HasPrefix(lines[len(lines)-1], "└── ") {
+		t.Errorf("unexpected tree:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	wnInstance.PrintTree(&buf, dog, Hyponym, 0)
+	if !strings.Contains(buf.String(), "│   ├── ") && !strings.Contains(buf.String(), "    ├── ") {
+		t.Errorf("expected nested levels, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	wnInstance.PrintTree(&buf, wnInstance.Roots(Noun)[0], Hyponym, 0)
+	if got := strings.Count(buf.String(), "\n"); got != maxTreeNodes+1 || !strings.Contains(buf.String(), "truncated") {
+		t.Errorf("expected output to be truncated at %d synsets, got %d lines", maxTreeNodes, got)
+	}
+
+	buf.Reset()
+	wnInstance.PrintTree(&buf, Lookup{}, Hyponym, 0)
+	if buf.Len() != 0 {
+		t.Errorf("expected no tree for the zero Lookup, got %q", buf.String())
+	}
+}
+
+func TestPrintTreeSharedSubtrees(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 01 top 0 002 ~ 00000002 n 0000 ~ 00000003 n 0000 | the root\n" +
+			"00000002 03 n 01 left 0 001 ~ 00000004 n 0000 | a child\n" +
+			"00000003 03 n 01 right 0 001 ~ 00000004 n 0000 | another child\n" +
+			"00000004 03 n 01 shared 0 001 ~ 00000001 n 0000 | a child of both, with a cycle back\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	top, _ := h.LookupByOffset(Noun, 1)
+
+	var buf bytes.Buffer
+	h.PrintTree(&buf, top, Hyponym, 0)
+	expected := "top: the root\n" +
+		"├── left: a child\n" +
+		"│   └── shared: a child of both, with a cycle back\n" +
+		"│       └── top: the root (see above)\n" +
+		"└── right: another child\n" +
+		"    └── shared: a child of both, with a cycle back (see above)\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected tree:\n%s\nwant:\n%s", buf.String(), expected)
+	}
+}
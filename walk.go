@@ -0,0 +1,99 @@
+package wnram
+
+import "fmt"
+
+// The order Walk visits synsets in
+type WalkOrder int
+
+const (
+	BreadthFirst WalkOrder = iota
+	DepthFirst
+)
+
+// What Walk traverses
+type WalkOptions struct {
+	// The relations to follow, e.g. Hyponym | InstanceHyponym
+	Relations Relation
+	// How many steps from the start to go at most, no limit if <= 0
+	MaxDepth int
+	Order    WalkOrder
+}
+
+// one step of a walk: a synset, the member it was reached through and how
+type walkStep struct {
+	node  Lookup
+	depth int
+	rel   Relation
+}
+
+// Traverse the graph of synsets reachable from start through
+// opts.Relations, calling visit for each synset once, the first time it
+// is reached: the start itself at depth 0 with a zero relation, then the
+// synsets it points to at depth 1 with the relation leading there, and so
+// on.  Both semantic relations and the lexical relations of any member
+// are followed; a node reached through a lexical relation is searched for
+// as the target word, any other as its lemma.  Returning false from visit
+// prunes the walk below that node.  Only an invalid start or options are
+// errors.
+func (h *Handle) Walk(start Lookup, opts WalkOptions, visit func(node Lookup, depth int, rel Relation) bool) error {
+	if start.cluster == nil {
+		return fmt.Errorf("walk from an empty lookup")
+	}
+	if opts.Relations == 0 {
+		return fmt.Errorf("walk without relations to follow")
+	}
+
+	seen := map[*cluster]bool{start.cluster: true}
+	// the unvisited neighbours of a node, marking them seen
+	next := func(s walkStep) (steps []walkStep) {
+		add := func(target *cluster, word string, rel Relation) {
+			if !seen[target] {
+				seen[target] = true
+				steps = append(steps, walkStep{Lookup{word: word, cluster: target}, s.depth + 1, rel})
+			}
+		}
+		c := s.node.cluster
+		for _, rel := range c.relations {
+			if rel.rel&opts.Relations != 0 {
+				add(rel.target, rel.target.words[0].word, rel.rel)
+			}
+		}
+		for _, m := range c.words {
+			for _, rel := range m.relations {
+				if rel.rel&opts.Relations != 0 {
+					add(rel.target, rel.target.words[rel.wordNumber].word, rel.rel)
+				}
+			}
+		}
+		return steps
+	}
+	expand := func(s walkStep) bool {
+		return opts.MaxDepth <= 0 || s.depth < opts.MaxDepth
+	}
+
+	switch opts.Order {
+	case BreadthFirst:
+		queue := []walkStep{{start, 0, 0}}
+		for len(queue) > 0 {
+			s := queue[0]
+			queue = queue[1:]
+			if visit(s.node, s.depth, s.rel) && expand(s) {
+				queue = append(queue, next(s)...)
+			}
+		}
+	case DepthFirst:
+		var walk func(s walkStep)
+		walk = func(s walkStep) {
+			if !visit(s.node, s.depth, s.rel) || !expand(s) {
+				return
+			}
+			for _, n := range next(s) {
+				walk(n)
+			}
+		}
+		walk(walkStep{start, 0, 0})
+	default:
+		return fmt.Errorf("unknown walk order %d", opts.Order)
+	}
+	return nil
+}
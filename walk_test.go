@@ -0,0 +1,71 @@
+package wnram
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	dog := specificSense(t, "dog", Noun, "domesticated")
+
+	var words []string
+	last := 0
+	err := wnInstance.Walk(dog, WalkOptions{Relations: generalizations}, func(node Lookup, depth int, rel Relation) bool {
+		if depth == 0 && (rel != 0 || !node.Equal(dog)) {
+			t.Errorf("walk should start at dog, got %s via %v", node.SynsetID(), rel)
+		}
+		if depth < last {
+			t.Errorf("breadth first walk went from depth %d back to %d", last, depth)
+		}
+		last = depth
+		words = append(words, node.Lemma())
+		return true
+	})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if !setContains(words, []string{"dog", "canine", "animal", "entity"}) {
+		t.Errorf("unexpected hypernyms of dog: %v", words)
+	}
+
+	// depth limit and pruning agree
+	var limited, pruned []string
+	wnInstance.Walk(dog, WalkOptions{Relations: Hypernym, MaxDepth: 1, Order: DepthFirst}, func(node Lookup, depth int, rel Relation) bool {
+		limited = append(limited, node.SynsetID())
+		return true
+	})
+	wnInstance.Walk(dog, WalkOptions{Relations: Hypernym}, func(node Lookup, depth int, rel Relation) bool {
+		pruned = append(pruned, node.SynsetID())
+		return depth < 1
+	})
+	if len(limited) < 2 || !slices.Equal(limited, pruned) {
+		t.Errorf("MaxDepth 1 visited %v, pruning below depth 1 visited %v", limited, pruned)
+	}
+
+	// lexical relations are followed through the member's word
+	good := specificSense(t, "good", Adjective, "desirable or positive")
+	found := false
+	wnInstance.Walk(good, WalkOptions{Relations: Antonym, MaxDepth: 1}, func(node Lookup, depth int, rel Relation) bool {
+		if depth == 1 && rel == Antonym && node.Word() == "bad" {
+			found = true
+		}
+		return true
+	})
+	if !found {
+		t.Errorf("expected to reach bad from good")
+	}
+}
+
+func TestWalkErrors(t *testing.T) {
+	visit := func(Lookup, int, Relation) bool { return true }
+	if err := wnInstance.Walk(Lookup{}, WalkOptions{Relations: Hypernym}, visit); err == nil {
+		t.Errorf("expected an error for an empty start")
+	}
+	dog := firstSense(t, wnInstance, "dog", Noun)
+	if err := wnInstance.Walk(dog, WalkOptions{}, visit); err == nil {
+		t.Errorf("expected an error without relations")
+	}
+	if err := wnInstance.Walk(dog, WalkOptions{Relations: Hypernym, Order: 7}, visit); err == nil {
+		t.Errorf("expected an error for an unknown order")
+	}
+}
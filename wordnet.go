@@ -1,26 +1,82 @@
 package wnram
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"maps"
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
 	"slices"
 	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
 )
 
 // An initialized read-only, in-ram instance of the wordnet database.
-// May safely be shared by multiple threads of execution
+// May safely be shared by multiple threads of execution, including while
+// exceptions are being added with AddException
 type Handle struct {
 	index      map[string][]*cluster
 	db         []*cluster
+	byID       map[string]*cluster
 	exceptions map[string]string
+	// all base forms listed by each part of speech's exception file
+	excByPOS map[PartOfSpeech]map[string][]string
+	// the reverse of excByPOS: base form -> irregular inflected forms
+	surfaces map[PartOfSpeech]map[string][]string
+	// exceptions added at runtime by AddException, guarded by mu
+	mu             sync.RWMutex
+	userExceptions map[PartOfSpeech]map[string]string
+	userSuffixes   map[PartOfSpeech][]suffixRule
+	roots          map[PartOfSpeech][]*cluster
+	opts           Options
+	// metaphone code -> lemmas, only built with Options.PhoneticIndex
+	phonetic map[string][]string
+	// word -> the collocations containing it, only built with
+	// Options.CollocationIndex
+	collocations map[string][]string
+	// nil unless Options.MorphCacheSize is set
+	morphCache *lru[morphKey, string]
+	// Lookup results, and the count of morphology changes (guarded by mu)
+	// so that results computed before one aren't cached after it
+	lookupCache *lru[string, lookupPage]
+	morphGen    uint64
+	// data computed on first use, see Precompute
+	derived derived
+	// whether sense numbers and tag counts were loaded from index.sense
+	hasFrequencies bool
+	// per part of speech polysemy figures, reported by Stats
+	polysemy polysemy
+	// the number of synsets skipped for having no members
+	emptySynsets int
+	// domain synset -> the synsets (or words) assigned to it
+	domains map[*cluster][]Lookup
+	// created by NewIndexOnly, whose index points to empty synsets, and
+	// the number of tagged senses of each lemma its index files give
+	indexOnly    bool
+	taggedSenses map[morphKey]int
 }
 
-// The results of a search against the wordnet database
+// The results of a search against the wordnet database.  Results come in
+// a stable order that only depends on the data files: Lookup returns the
+// senses of a word in sense number order only when index.sense is
+// loaded, in data file order otherwise (see Handle.Lookup); Synonyms
+// lists members in synset order and Related sorts by target SynsetID.
 type Lookup struct {
 	word    string   // the word the user searched for
 	cluster *cluster // the discoverd synonym set
+	matched string   // the (normalized) base form word was found as, if it differs
+	// what Criteria.PreloadRelations reached from it, a pointer to keep
+	// Lookups comparable
+	preloaded *[]Lookup
 }
 
 type syntacticRelation struct {
@@ -35,17 +91,32 @@ type semanticRelation struct {
 }
 
 type word struct {
-	sense     uint8
-	word      string
-	relations []syntacticRelation
+	sense       uint8
+	word        string
+	marker      string // syntactic marker of an adjective, e.g. "p"
+	relations   []syntacticRelation
+	senseNumber int // from index.sense, zero if unknown
+	tagCount    int // from index.sense, zero if unknown
 }
 
 type cluster struct {
 	pos       PartOfSpeech
+	satellite bool
+	lexFile   int // the number of the lexicographer file it came from
 	words     []word
 	gloss     string
 	relations []semanticRelation
 	debug     string
+	// pointers with a symbol this package doesn't know
+	unknownPointers []unknownPointer
+	// of the handle it was loaded by, nil for index-only stand ins
+	derived *derived
+}
+
+// the offset of the synset in its data file together with a part of
+// speech letter, e.g. "02086723-n"
+func (c *cluster) id() string {
+	return c.debug + "-" + c.pos.letter()
 }
 
 // Parts of speech
@@ -90,8 +161,15 @@ const (
 	Adverb
 )
 
+// Stands for a word of none of the parts of speech WordNet covers (a
+// pronoun, determiner, preposition, ...) or for no word at all, see
+// MorphInContext
+const NoPOS PartOfSpeech = 255
+
 func (pos PartOfSpeech) String() string {
 	switch pos {
+	case NoPOS:
+		return "none"
 	case Noun:
 		return "noun"
 	case Verb:
@@ -104,6 +182,21 @@ func (pos PartOfSpeech) String() string {
 	return "unknown"
 }
 
+// the single letter used for pos in the wordnet data files
+func (pos PartOfSpeech) letter() string {
+	switch pos {
+	case Noun:
+		return "n"
+	case Verb:
+		return "v"
+	case Adjective:
+		return "a"
+	case Adverb:
+		return "r"
+	}
+	return "?"
+}
+
 // The ways in which synonym clusters may be related to others.
 type Relation uint32
 
@@ -143,12 +236,185 @@ const (
 	ParticipleOfVerb
 	RelatedForm
 	SimilarTo
+	// Links closely related senses of a verb ($ pointers), e.g. the
+	// running on foot senses of "run" but not its "operate" senses.
 	VerbGroup
 )
 const Pertainym = DerivedFromAdjective
 
+var relationNames = map[Relation]string{
+	AlsoSee:                   "also see",
+	Antonym:                   "antonym",
+	Attribute:                 "attribute",
+	Cause:                     "cause",
+	DerivationallyRelatedForm: "derivationally related form",
+	DerivedFromAdjective:      "derived from adjective",
+	InDomainRegion:            "in domain region",
+	InDomainTopic:             "in domain topic",
+	InDomainUsage:             "in domain usage",
+	ContainsDomainRegion:      "contains domain region",
+	ContainsDomainTopic:       "contains domain topic",
+	ContainsDomainUsage:       "contains domain usage",
+	Entailment:                "entailment",
+	Hypernym:                  "hypernym",
+	InstanceHypernym:          "instance hypernym",
+	InstanceHyponym:           "instance hyponym",
+	Hyponym:                   "hyponym",
+	MemberMeronym:             "member meronym",
+	PartMeronym:               "part meronym",
+	SubstanceMeronym:          "substance meronym",
+	MemberHolonym:             "member holonym",
+	PartHolonym:               "part holonym",
+	SubstanceHolonym:          "substance holonym",
+	ParticipleOfVerb:          "participle of verb",
+	RelatedForm:               "related form",
+	SimilarTo:                 "similar to",
+	VerbGroup:                 "verb group",
+}
+
+// the pointer symbols used for each relation in the wordnet data files
+var relationSymbols = map[Relation]string{
+	AlsoSee:                   "^",
+	Antonym:                   "!",
+	Attribute:                 "=",
+	Cause:                     ">",
+	DerivationallyRelatedForm: "+",
+	DerivedFromAdjective:      "\\",
+	InDomainRegion:            "-r",
+	InDomainTopic:             "-c",
+	InDomainUsage:             "-u",
+	ContainsDomainRegion:      ";r",
+	ContainsDomainTopic:       ";c",
+	ContainsDomainUsage:       ";u",
+	Entailment:                "*",
+	Hypernym:                  "@",
+	InstanceHypernym:          "@i",
+	InstanceHyponym:           "~i",
+	Hyponym:                   "~",
+	MemberMeronym:             "%m",
+	PartMeronym:               "%p",
+	SubstanceMeronym:          "%s",
+	MemberHolonym:             "#m",
+	PartHolonym:               "#p",
+	SubstanceHolonym:          "#s",
+	ParticipleOfVerb:          "<",
+	SimilarTo:                 "&",
+	VerbGroup:                 "$",
+}
+
+// the relation WordNet records in the other direction, see Inverse
+var inverseRelations = map[Relation]Relation{
+	Antonym:                   Antonym,
+	Attribute:                 Attribute,
+	DerivationallyRelatedForm: DerivationallyRelatedForm,
+	InDomainRegion:            ContainsDomainRegion,
+	InDomainTopic:             ContainsDomainTopic,
+	InDomainUsage:             ContainsDomainUsage,
+	ContainsDomainRegion:      InDomainRegion,
+	ContainsDomainTopic:       InDomainTopic,
+	ContainsDomainUsage:       InDomainUsage,
+	Hypernym:                  Hyponym,
+	Hyponym:                   Hypernym,
+	InstanceHypernym:          InstanceHyponym,
+	InstanceHyponym:           InstanceHypernym,
+	MemberMeronym:             MemberHolonym,
+	PartMeronym:               PartHolonym,
+	SubstanceMeronym:          SubstanceHolonym,
+	MemberHolonym:             MemberMeronym,
+	PartHolonym:               PartMeronym,
+	SubstanceHolonym:          SubstanceMeronym,
+	SimilarTo:                 SimilarTo,
+	VerbGroup:                 VerbGroup,
+}
+
+// The relation pointing the other way: when a synset points to another
+// as rel, the other points back to it as the inverse, e.g. Hyponym for
+// Hypernym.  Symmetric relations such as Antonym and SimilarTo are their
+// own inverse.  False for relations WordNet doesn't record in both
+// directions (AlsoSee, Entailment, Cause, ParticipleOfVerb, Pertainym)
+// and for anything but a single relation.
+func Inverse(rel Relation) (Relation, bool) {
+	inverse, ok := inverseRelations[rel]
+	return inverse, ok
+}
+
+func (r Relation) name() string {
+	if n, ok := relationNames[r]; ok {
+		return n
+	}
+	return "unknown"
+}
+
+// Every relation type, in the order of their bits
+var AllRelations = []Relation{
+	AlsoSee, Antonym, Attribute, Cause, DerivationallyRelatedForm,
+	DerivedFromAdjective, InDomainRegion, InDomainTopic, InDomainUsage,
+	ContainsDomainRegion, ContainsDomainTopic, ContainsDomainUsage,
+	Entailment, Hypernym, InstanceHypernym, InstanceHyponym, Hyponym,
+	MemberMeronym, PartMeronym, SubstanceMeronym, MemberHolonym,
+	PartHolonym, SubstanceHolonym, ParticipleOfVerb, RelatedForm,
+	SimilarTo, VerbGroup,
+}
+
+// The name of the relation, e.g. "hypernym" or "also see", or those of a
+// combination of relations joined by "|" in the order of AllRelations.
+// "none" for no relation.
+func (r Relation) String() string {
+	if n, ok := relationNames[r]; ok {
+		return n
+	}
+	if r == 0 {
+		return "none"
+	}
+	var names []string
+	for _, rel := range AllRelations {
+		if r&rel != 0 {
+			names = append(names, rel.name())
+			r &^= rel
+		}
+	}
+	if r != 0 {
+		names = append(names, fmt.Sprintf("Relation(%#x)", uint32(r)))
+	}
+	return strings.Join(names, "|")
+}
+
+// The relation named s as by String ("hypernym", "also see") or with a
+// WordNet pointer symbol ("@", "^").  Names are matched case
+// insensitively, with underscores or hyphens for spaces ("also_see"), and
+// "pertainym" is accepted for DerivedFromAdjective.  False if s isn't a
+// single relation.
+func ParseRelation(s string) (Relation, bool) {
+	s = strings.TrimSpace(s)
+	if r := symbolRelation(s); r != 0 {
+		return r, true
+	}
+	name := strings.Join(strings.Fields(strings.ToLower(strings.NewReplacer("_", " ", "-", " ").Replace(s))), " ")
+	if name == "pertainym" {
+		return Pertainym, true
+	}
+	for _, r := range AllRelations {
+		if relationNames[r] == name {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+// stands in for the synset of the zero Lookup
+var noSynset = &cluster{}
+
+// the synset of w, an empty one for the zero Lookup so that accessors
+// return zero values rather than panic
+func (w *Lookup) synset() *cluster {
+	if w.cluster == nil {
+		return noSynset
+	}
+	return w.cluster
+}
+
 func (w *Lookup) String() string {
-	return fmt.Sprintf("%q (%s)", w.word, w.cluster.pos.String())
+	return fmt.Sprintf("%q (%s)", w.word, w.synset().pos.String())
 }
 
 // The specific word that was found
@@ -156,52 +422,159 @@ func (w *Lookup) Word() string {
 	return w.word
 }
 
-// A canonical synonym for this word
+// The headword of this meaning: the first member of its synset as listed
+// in the data file, with the case WordNet gives it and without any
+// adjective marker.  It doesn't depend on the word searched for, which
+// may be a different member (for "awesome" it is "amazing"; use Word for
+// the searched word), and stays the same for a given database.  WordNet
+// doesn't say how it orders members, but the first one is usually the
+// most common.  Empty for the zero Lookup.
 func (w *Lookup) Lemma() string {
-	return w.cluster.words[0].word
+	if words := w.synset().words; len(words) > 0 {
+		return words[0].word
+	}
+	return ""
+}
+
+// A stable identifier for this meaning made of the data file offset and
+// a part of speech letter, e.g. "02086723-n".  Suitable as a map key when
+// deduplicating results.
+func (w *Lookup) SynsetID() string {
+	if w.cluster == nil {
+		return ""
+	}
+	return w.cluster.id()
+}
+
+// A deterministic one line form of this synset for golden files, its
+// part of speech letter and offset followed by its members in data file
+// order, e.g. "n02086723{dog,domestic_dog,Canis_familiaris}".  It only
+// depends on the synset, not on the word that found it.
+func (w *Lookup) Canonical() string {
+	c := w.cluster
+	if c == nil {
+		return ""
+	}
+	words := make([]string, len(c.words))
+	for i, m := range c.words {
+		words[i] = strings.ReplaceAll(m.word, " ", "_")
+	}
+	return c.pos.letter() + c.debug + "{" + strings.Join(words, ",") + "}"
+}
+
+// Whether two results refer to the same meaning (same part of speech and
+// offset), regardless of which word was used to find them.  Results for the
+// same synset obtained from one Handle always compare equal.
+func (w *Lookup) Equal(other Lookup) bool {
+	return w.synset().pos == other.synset().pos && w.synset().debug == other.synset().debug
 }
 
 // A description of this meaning
 func (w *Lookup) Gloss() string {
-	return w.cluster.gloss
+	return w.synset().gloss
 }
 
+// A multi-line, human readable description of this meaning including
+// its synset id, members, relations and gloss
 func (w *Lookup) DumpStr() string {
-	s := fmt.Sprintf("Word: %s\n", w.String())
-	s += "Synonyms: "
+	var b strings.Builder
+	w.DumpTo(&b)
+	return b.String()
+}
+
+// Write the output of DumpStr to the given writer
+func (w *Lookup) DumpTo(out io.Writer) {
+	fmt.Fprintf(out, "Word: %s\n", w.String())
+	fmt.Fprintf(out, "Synset: %s\n", w.SynsetID())
+	fmt.Fprintf(out, "Synonyms: ")
 	words := []string{}
 
-	for _, w := range w.cluster.words {
+	for _, w := range w.synset().words {
 		words = append(words, w.word)
 	}
 
-	s += strings.Join(words, ", ") + "\n"
-	s += fmt.Sprintf("%d semantic relationships\n", len(w.cluster.relations))
-	s += "| " + w.cluster.gloss + "\n"
-
-	return s
+	fmt.Fprintf(out, "%s\n", strings.Join(words, ", "))
+	fmt.Fprintf(out, "%d semantic relationships\n", len(w.synset().relations))
+	for _, rel := range w.synset().relations {
+		fmt.Fprintf(out, "  %s: %s (%s)\n", rel.rel.name(), rel.target.words[0].word, rel.target.id())
+	}
+	for _, word := range w.synset().words {
+		for _, rel := range word.relations {
+			fmt.Fprintf(out, "  %s: %s -> %s (%s)\n", rel.rel.name(), word.word, rel.target.words[rel.wordNumber].word, rel.target.id())
+		}
+	}
+	fmt.Fprintf(out, "Richness: %d\n", w.SemanticRichness())
+	fmt.Fprintf(out, "| %s\n", w.synset().gloss)
 }
 
+// Write the output of DumpStr to stdout
 func (w *Lookup) Dump() {
-	fmt.Printf("%s", w.DumpStr())
+	w.DumpTo(os.Stdout)
 }
 
 func (w *Lookup) POS() PartOfSpeech {
-	return w.cluster.pos
+	return w.synset().pos
+}
+
+// The syntactic marker restricting where the searched adjective may
+// appear relative to the noun it modifies: "p" (predicate position, "the
+// baby is awake"), "a" (prenominal, "an elect official") or "ip"
+// (immediately postnominal, "food galore").  WordNet appends these to
+// lemmas in its data files, e.g. "awake(p)"; they are removed from the
+// words Word, Lemma and Synonyms return.  Empty for most words.
+func (w *Lookup) SyntacticMarker() string {
+	if m := w.member(); m != nil {
+		return m.marker
+	}
+	return ""
 }
 
+// Whether this is an adjective satellite synset (ss_type "s"), which
+// WordNet attaches to a head adjective synset through SimilarTo.  POS
+// reports satellites as plain adjectives.
+func (w *Lookup) IsSatellite() bool {
+	return w.synset().satellite
+}
+
+// All members of this synset in data file order, including the word that
+// was searched for (under its lemma spelling, e.g. "goose" for "geese",
+// see Matched).  Filter out Word or Matched for the other members only.
 func (w *Lookup) Synonyms() (synonyms []string) {
-	for _, w := range w.cluster.words {
+	for _, w := range w.synset().words {
+		synonyms = append(synonyms, w.word)
+	}
+	return synonyms
+}
+
+// The members of this synset ordered by how often each was tagged with
+// this meaning in the sense-tagged corpora, most common first.  Members
+// with equal counts keep their synset order.  Without frequency data
+// (index.sense) this is the same as Synonyms.
+func (w *Lookup) SynonymsByFrequency() []string {
+	words := slices.Clone(w.synset().words)
+	slices.SortStableFunc(words, func(a, b word) int {
+		return b.tagCount - a.tagCount
+	})
+
+	synonyms := make([]string, 0, len(words))
+	for _, w := range words {
 		synonyms = append(synonyms, w.word)
 	}
 	return synonyms
 }
 
 // Get words related to this word.  r is a bitfield of relation types
-// to include
+// to include.  The results are complete Lookups of the target synsets,
+// searched for as their lemma (or, for lexical relations, the target
+// word), so their glosses and relations are available as for any other
+// result.  They are sorted by target SynsetID, so the order is the same
+// whatever the order of the pointers in the data files; a target related
+// both semantically and lexically (through the searched word) is listed
+// twice, semantic relation first.  Always empty for the results of a
+// handle from LoadDictionary, which has no relations.
 func (w *Lookup) Related(r Relation) (relationships []Lookup) {
 	// first look for semantic relationships
-	for _, rel := range w.cluster.relations {
+	for _, rel := range w.synset().relations {
 		if rel.rel&r != Relation(0) {
 			relationships = append(relationships, Lookup{
 				word:    rel.target.words[0].word,
@@ -211,34 +584,253 @@ func (w *Lookup) Related(r Relation) (relationships []Lookup) {
 	}
 
 	// next let's look for syntactic relationships
-	key := normalize(w.word)
-	for _, word := range w.cluster.words {
-		if key == word.word {
-			for _, rel := range word.relations {
-				if rel.rel&r != Relation(0) {
-					relationships = append(relationships, Lookup{
-						word:    rel.target.words[rel.wordNumber].word,
-						cluster: rel.target,
-					})
-				}
-			}
+	if m := w.member(); m != nil {
+		relationships = append(relationships, m.related(r)...)
+	}
+
+	sortBySynsetID(relationships)
+	return relationships
+}
+
+// sorts results by SynsetID, keeping the order of those with the same id
+func sortBySynsetID(results []Lookup) {
+	slices.SortStableFunc(results, func(a, b Lookup) int {
+		return strings.Compare(a.SynsetID(), b.SynsetID())
+	})
+}
+
+// The synsets Criteria.PreloadRelations reached from this result, in the
+// order a breadth first Walk visits them (nearest first) and without the
+// result itself.  Nil if the Lookup wasn't found with PreloadRelations,
+// which includes those Related and the other traversals return.
+func (w *Lookup) Preloaded() []Lookup {
+	if w.preloaded == nil {
+		return nil
+	}
+	return slices.Clone(*w.preloaded)
+}
+
+// Only the relationships in r that WordNet records between the specific
+// member word of this synset and a specific word of the target synset
+// (lexical relations such as antonymy and derivation), e.g. for the
+// synset {breathe, respire} RelatedFrom("respire", DerivationallyRelatedForm)
+// yields "respiration" and "respirator" but not "breathing".  Each result's
+// Word() is the target word.  Sorted by target SynsetID like Related;
+// empty if word isn't a member.
+func (w *Lookup) RelatedFrom(word string, r Relation) []Lookup {
+	key := normalize(word)
+	for i := range w.synset().words {
+		if normalize(w.synset().words[i].word) == key {
+			related := w.synset().words[i].related(r)
+			sortBySynsetID(related)
+			return related
 		}
 	}
+	return nil
+}
 
+// The normalized base form the searched for word was found as, e.g.
+// "goose" for "geese", or "" if it was found as is
+func (w *Lookup) Matched() string {
+	return w.matched
+}
+
+// the lexical relations of a single synset member
+func (m *word) related(r Relation) (relationships []Lookup) {
+	for _, rel := range m.relations {
+		if rel.rel&r != Relation(0) {
+			relationships = append(relationships, Lookup{
+				word:    rel.target.words[rel.wordNumber].word,
+				cluster: rel.target,
+			})
+		}
+	}
 	return relationships
 }
 
+// the member of the synset matching the word that was searched for, or
+// nil if there is none (e.g. for synsets found through a relation's
+// target word when it isn't a member)
+func (w *Lookup) member() *word {
+	key := w.matched
+	if key == "" {
+		key = normalize(w.word)
+	}
+	for i := range w.synset().words {
+		if normalize(w.synset().words[i].word) == key {
+			return &w.synset().words[i]
+		}
+	}
+	return nil
+}
+
+// All member words of the synsets Related(r) returns, deduplicated, in
+// the order they are first encountered
+func (w *Lookup) RelatedWords(r Relation) (words []string) {
+	seen := map[string]bool{}
+	for _, rel := range w.Related(r) {
+		for _, m := range rel.cluster.words {
+			if !seen[m.word] {
+				seen[m.word] = true
+				words = append(words, m.word)
+			}
+		}
+	}
+	return words
+}
+
+// A word along with its part of speech
+type WordPOS struct {
+	Word string
+	POS  PartOfSpeech
+}
+
+// Like RelatedWords, with the part of speech of each word's synset, for
+// relations that cross parts of speech: for the "beauty" (noun) synset
+// DerivationallyRelatedForm gives {beautiful, Adjective} and {beautify,
+// Verb}.  Deduplicated on word and part of speech.
+func (w *Lookup) RelatedWordsPOS(r Relation) (words []WordPOS) {
+	seen := map[WordPOS]bool{}
+	for _, rel := range w.Related(r) {
+		for _, m := range rel.cluster.words {
+			wp := WordPOS{m.word, rel.cluster.pos}
+			if !seen[wp] {
+				seen[wp] = true
+				words = append(words, wp)
+			}
+		}
+	}
+	return words
+}
+
+// The number of relation edges of the synset: its semantic relations
+// plus the lexical relations of all its members, of every type.  A rough
+// measure of how central and well described a concept is.
+func (w *Lookup) SemanticRichness() int {
+	count := len(w.synset().relations)
+	for _, m := range w.synset().words {
+		count += len(m.relations)
+	}
+	return count
+}
+
+// The number of relationships Related(r) would return, without
+// building them.  r is a bitfield of relation types to include
+func (w *Lookup) RelationCount(r Relation) (count int) {
+	for _, rel := range w.synset().relations {
+		if rel.rel&r != Relation(0) {
+			count++
+		}
+	}
+
+	if m := w.member(); m != nil {
+		for _, rel := range m.relations {
+			if rel.rel&r != Relation(0) {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// the part of speech of an exception file, named after the data file it
+// belongs to (e.g. "noun.exc")
+func excFilePOS(name string) (PartOfSpeech, bool) {
+	switch strings.TrimSuffix(name, ".exc") {
+	case "noun":
+		return Noun, true
+	case "verb":
+		return Verb, true
+	case "adj":
+		return Adjective, true
+	case "adv":
+		return Adverb, true
+	}
+	return 0, false
+}
+
+// Optional features of a Handle, most of which trade memory or load time
+// for faster queries.  The zero value gives the default behavior.
+type Options struct {
+	// Build a metaphone index of all lemmas at load time, needed by
+	// SoundsLike
+	PhoneticIndex bool
+	// Index the words of collocations at load time, making Collocations
+	// a map lookup rather than a scan of all lemmas
+	CollocationIndex bool
+	// Remember up to this many MorphWord results, zero disables caching
+	MorphCacheSize int
+	// Remember the results of up to this many Lookup (and LookupPage)
+	// calls, zero disables caching.  Hits cost a copy of the result slice.
+	LookupCacheSize int
+	// Case fold search strings using this language's rules, for input that
+	// a non-English locale may have case mapped upstream: e.g. with
+	// language.Turkish "ISTANBUL" (lower cased "ıstanbul") matches
+	// "istanbul", and with any language "GLAß" matches "glass".  Defaults
+	// to plain lower casing, which suits English.
+	CaseFold language.Tag
+	// Join all verb hierarchies under one virtual root so depth based
+	// similarity measures work for any pair of verbs.  See
+	// WuPalmerSimilarity.
+	VirtualVerbRoot bool
+	// Ignore WordNet's exception lists (noun.exc, verb.exc, ...) in
+	// MorphWord, MorphDetailed and Lookup, leaving the suffix rules and
+	// exceptions added with AddException, e.g. to measure what the lists
+	// contribute.  "geese" then has no base form.
+	DisableMorphExceptions bool
+	// Make IsValidWord reject collocations, lemmas of several words or
+	// joined by hyphens ("ice cream", "well-known")
+	ValidWordsExcludeCollocations bool
+	// Make IsValidWord reject proper nouns, lemmas WordNet only lists
+	// capitalized ("Paris", but not "china" which is also a noun)
+	ValidWordsExcludeProperNouns bool
+	// Where to report what loading skips or can't find (missing data
+	// files, synsets without members, unknown pointer symbols) as warnings
+	// and other details (ignored files, load time) at debug level.  Nil
+	// discards them.
+	Logger *slog.Logger
+}
+
 // Initialize a new in-ram WordNet databases reading files from the
-// specified directory.
+// specified directory.  Synsets listing no members are skipped (see
+// Stats.EmptySynsets).
 func New(dir string) (*Handle, error) {
-	type ix struct {
-		index string
-		pos   PartOfSpeech
+	return NewWithOptions(dir, Options{})
+}
+
+// the directories NewDefault searches after those named by the
+// environment, where Linux distributions and the WordNet installer put the
+// data files
+var defaultDataDirs = []string{"/usr/share/wordnet", "/usr/local/WordNet-3.0/dict"}
+
+// Initialize a new in-ram WordNet database from the first directory
+// holding a data.noun file among $WNSEARCHDIR, $WNHOME/dict (the
+// variables the WordNet tools use, in the order they apply them),
+// /usr/share/wordnet and /usr/local/WordNet-3.0/dict.  The error lists
+// the directories searched if none has the data files.
+func NewDefault() (*Handle, error) {
+	var searched []string
+	if dir := os.Getenv("WNSEARCHDIR"); dir != "" {
+		searched = append(searched, dir)
+	}
+	if home := os.Getenv("WNHOME"); home != "" {
+		searched = append(searched, filepath.Join(home, "dict"))
 	}
+	searched = append(searched, defaultDataDirs...)
 
-	byOffset := map[ix]*cluster{}
-	exceptions := map[string]string{}
+	for _, dir := range searched {
+		if _, err := os.Stat(filepath.Join(dir, "data.noun")); err == nil {
+			return New(dir)
+		}
+	}
+	return nil, fmt.Errorf("no wordnet data files found in %s", strings.Join(searched, ", "))
+}
 
+// Initialize a new in-ram WordNet database reading files from the
+// specified directory, enabling the given optional features.
+func NewWithOptions(dir string, opts Options) (*Handle, error) {
+	l := newLoader(opts.Logger)
 	err := filepath.Walk(dir, func(filename string, info os.FileInfo, err error) error {
 		if err != nil || info.IsDir() {
 			return err
@@ -249,93 +841,333 @@ func New(dir string) (*Handle, error) {
 			return nil
 		}
 
-		// read data files
-		if strings.HasPrefix(path.Base(filename), "data") {
-			err = inPlaceReadLineFromPath(filename, func(data []byte, line, offset int64) error {
-				if p, err := parseLine(data, line); err != nil {
-					return fmt.Errorf("%s", err)
-				} else if p != nil {
-					// first, let's identify the cluster
-					index := ix{p.byteOffset, p.pos}
-					c, ok := byOffset[index]
+		return l.load(filename, func(cb func([]byte, int64, int64) error) error {
+			return inPlaceReadLineFromPath(filename, cb)
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return l.handle(opts)
+}
+
+// The files NewFromOpener asks for, in the order New would read them from
+// a directory
+var openerFiles = []string{
+	"adj.exc", "adv.exc",
+	"data.adj", "data.adv", "data.noun", "data.verb",
+	"index.sense",
+	"noun.exc", "verb.exc",
+}
+
+// Initialize a new in-ram WordNet database reading files from a custom
+// source, e.g. an embedded archive or a remote store.  open is called
+// with the standard WordNet file names, without any directory:
+// "data.noun", "data.verb", "data.adj", "data.adv", "noun.exc",
+// "verb.exc", "adj.exc", "adv.exc" and "index.sense" (which supplies
+// sense numbers and frequencies).  Files for which open returns an error
+// matching fs.ErrNotExist are skipped, like files missing from a
+// directory given to New; any other error aborts loading.  All the files
+// are opened concurrently, then read and closed one after the other.
+func NewFromOpener(open func(name string) (io.ReadCloser, error)) (*Handle, error) {
+	return NewFromOpenerWithOptions(open, Options{})
+}
+
+// NewFromOpener, enabling the given optional features
+func NewFromOpenerWithOptions(open func(name string) (io.ReadCloser, error), opts Options) (*Handle, error) {
+	files := make([]io.ReadCloser, len(openerFiles))
+	errs := make([]error, len(openerFiles))
+	var wg sync.WaitGroup
+	for i, name := range openerFiles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			files[i], errs[i] = open(name)
+		}()
+	}
+	wg.Wait()
+
+	defer func() {
+		for _, f := range files {
+			if f != nil {
+				f.Close()
+			}
+		}
+	}()
+
+	l := newLoader(opts.Logger)
+	for i, name := range openerFiles {
+		if errors.Is(errs[i], fs.ErrNotExist) {
+			continue
+		}
+		if errs[i] != nil {
+			return nil, fmt.Errorf("opening %s: %w", name, errs[i])
+		}
+		err := l.load(name, func(cb func([]byte, int64, int64) error) error {
+			return inPlaceReadLine(files[i], cb)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return l.handle(opts)
+}
+
+// identifies a synset while loading
+type ix struct {
+	index string
+	pos   PartOfSpeech
+}
+
+// the state of a database being loaded
+type loader struct {
+	byOffset      map[ix]*cluster
+	exceptions    map[string]string
+	posExceptions map[PartOfSpeech]map[string][]string
+	senses        []*senseEntry
+	indexEntries  []indexEntry // only read by NewIndexOnly
+
+	logger *slog.Logger
+	start  time.Time
+	files  map[string]bool // the base names of the files read
+}
+
+// newLoader starts loading, reporting to logger (nil discards messages)
+func newLoader(logger *slog.Logger) *loader {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &loader{
+		byOffset:      map[ix]*cluster{},
+		exceptions:    map[string]string{},
+		posExceptions: map[PartOfSpeech]map[string][]string{},
+		logger:        logger,
+		start:         time.Now(),
+		files:         map[string]bool{},
+	}
+}
+
+// load the wordnet file filename, whose lines readLines passes to its
+// callback.  Files other than data, exception and sense index files are
+// ignored.
+func (l *loader) load(filename string, readLines func(cb func([]byte, int64, int64) error) error) error {
+	byOffset := l.byOffset
+	base := path.Base(filename)
+	if !strings.HasPrefix(base, "data") && base != "index.sense" && !strings.HasSuffix(base, ".exc") {
+		l.logger.Debug("ignoring file", "file", filename)
+		return nil
+	}
+	l.files[base] = true
+
+	// read data files
+	if strings.HasPrefix(path.Base(filename), "data") {
+		return readLines(func(data []byte, line, offset int64) error {
+			if p, err := parseLine(data, line); err != nil {
+				return fmt.Errorf("%s", err)
+			} else if p != nil {
+				// first, let's identify the cluster
+				index := ix{p.byteOffset, p.pos}
+				c, ok := byOffset[index]
+				if !ok {
+					c = &cluster{}
+					byOffset[index] = c
+				}
+
+				// now update
+				c.pos = p.pos
+				c.satellite = p.satellite
+				c.lexFile = int(p.fileNum)
+				c.words = p.words
+				c.gloss = p.gloss
+				c.debug = p.byteOffset
+
+				// now let's build relations
+				for _, r := range p.rels {
+					rindex := ix{r.offset, r.pos}
+					rcluster, ok := byOffset[rindex]
 					if !ok {
-						c = &cluster{}
-						byOffset[index] = c
+						// create the other side of the relationship
+						rcluster = &cluster{}
+						byOffset[rindex] = rcluster
 					}
-
-					// now update
-					c.pos = p.pos
-					c.words = p.words
-					c.gloss = p.gloss
-					c.debug = p.byteOffset
-
-					// now let's build relations
-					for _, r := range p.rels {
-						rindex := ix{r.offset, r.pos}
-						rcluster, ok := byOffset[rindex]
-						if !ok {
-							// create the other side of the relationship
-							rcluster = &cluster{}
-							byOffset[rindex] = rcluster
+					if r.rel == 0 {
+						// only available through Pointers
+						up := unknownPointer{symbol: r.symbol, target: rcluster}
+						if !r.isSemantic {
+							up.source, up.dest = int(r.source)+1, int(r.dest)+1
 						}
-						if r.isSemantic {
-							c.relations = append(c.relations, semanticRelation{
-								rel:    r.rel,
-								target: rcluster,
-							})
-						} else {
-							if int(r.source) >= len(c.words) {
-								return fmt.Errorf("%s:%d: error parsing relations, bogus source (words: %d, offset: %d) [%s]", filename, line, r.source, len(c.words), string(data))
-							}
-							c.words[r.source].relations = append(c.words[r.source].relations, syntacticRelation{
-								rel:        r.rel,
-								target:     rcluster,
-								wordNumber: r.dest,
-							})
+						c.unknownPointers = append(c.unknownPointers, up)
+					} else if r.isSemantic {
+						c.relations = append(c.relations, semanticRelation{
+							rel:    r.rel,
+							target: rcluster,
+						})
+					} else {
+						if int(r.source) >= len(c.words) {
+							return fmt.Errorf("%s:%d: error parsing relations, bogus source (words: %d, offset: %d) [%s]", filename, line, r.source, len(c.words), string(data))
 						}
+						c.words[r.source].relations = append(c.words[r.source].relations, syntacticRelation{
+							rel:        r.rel,
+							target:     rcluster,
+							wordNumber: r.dest,
+						})
 					}
-
 				}
-				return nil
-			})
 
-			return err
-		}
+			}
+			return nil
+		})
+	}
 
-		// read exception files
-		if strings.HasSuffix(path.Base(filename), ".exc") {
-			err = inPlaceReadLineFromPath(filename, func(data []byte, line, offset int64) error {
-				parts := strings.SplitN(string(data), " ", 2)
-				if len(parts) == 2 {
-					exceptions[parts[0]] = parts[1]
-				} else {
-					return fmt.Errorf("malformed exception line %d: %q", line, string(data))
-				}
+	// read the sense index, which is optional and only supplies
+	// sense numbers and frequencies
+	if path.Base(filename) == "index.sense" {
+		return readLines(func(data []byte, line, offset int64) error {
+			if len(strings.TrimSpace(string(data))) == 0 {
 				return nil
-			})
-		}
+			}
+			e, err := parseSenseLine(data)
+			if err != nil {
+				return fmt.Errorf("%s:%d: %s", filename, line, err)
+			}
+			l.senses = append(l.senses, e)
+			return nil
+		})
+	}
 
-		return err
-	})
+	// read exception files
+	if strings.HasSuffix(path.Base(filename), ".exc") {
+		pos, known := excFilePOS(path.Base(filename))
+		return readLines(func(data []byte, line, offset int64) error {
+			parts := strings.Fields(strings.ReplaceAll(string(data), "_", " "))
+			if len(parts) < 2 {
+				return fmt.Errorf("malformed exception line %d: %q", line, string(data))
+			}
+			// an inflected form may have several base forms
+			l.exceptions[parts[0]] = parts[1]
+			if known {
+				if l.posExceptions[pos] == nil {
+					l.posExceptions[pos] = map[string][]string{}
+				}
+				l.posExceptions[pos][parts[0]] = parts[1:]
+			}
+			return nil
+		})
+	}
 
-	if err != nil {
-		return nil, err
+	return nil
+}
+
+// index everything loaded into a handle
+func (l *loader) handle(opts Options) (*Handle, error) {
+	byOffset, exceptions, posExceptions, senses := l.byOffset, l.exceptions, l.posExceptions, l.senses
+
+	for _, e := range senses {
+		c, ok := byOffset[ix{e.offset, e.pos}]
+		if !ok {
+			return nil, fmt.Errorf("sense index refers to unknown synset %s-%s", e.offset, e.pos.letter())
+		}
+		if len(c.words) == 0 {
+			// an empty synset, skipped below
+			continue
+		}
+		if err := e.apply(c); err != nil {
+			return nil, err
+		}
 	}
 
 	h := Handle{
 		db:         make([]*cluster, 0, len(byOffset)),
 		index:      make(map[string][]*cluster),
+		byID:       make(map[string]*cluster, len(byOffset)),
 		exceptions: exceptions,
+		excByPOS:   posExceptions,
+		surfaces:   reverseExceptions(posExceptions),
+		roots:      make(map[PartOfSpeech][]*cluster),
+		opts:       opts,
+		morphCache: newLRU[morphKey, string](opts.MorphCacheSize),
+
+		lookupCache: newLRU[string, lookupPage](opts.LookupCacheSize),
+
+		hasFrequencies: len(senses) > 0,
+	}
+
+	// visit synsets in data file order so that iteration and lookup
+	// results are deterministic
+	keys := make([]ix, 0, len(byOffset))
+	for k := range byOffset {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b ix) int {
+		if a.pos != b.pos {
+			return int(a.pos) - int(b.pos)
+		}
+		return strings.Compare(a.index, b.index)
+	})
+
+	for _, name := range openerFiles {
+		if l.files[name] {
+			continue
+		}
+		if strings.HasPrefix(name, "data.") {
+			l.logger.Warn("data file not found", "file", name)
+		} else {
+			l.logger.Debug("optional file not found", "file", name)
+		}
+	}
+
+	// synsets whose data line lists no members (only possible in hand
+	// edited files) are left out, along with the pointers to them
+	empty := map[*cluster]bool{}
+	unknownSymbols := map[string]int{}
+	for _, k := range keys {
+		c := byOffset[k]
+		if len(c.words) == 0 && c.debug != "" {
+			l.logger.Warn("skipping synset without members", "synset", c.id())
+			empty[c] = true
+		}
+		for _, p := range c.unknownPointers {
+			unknownSymbols[p.symbol]++
+		}
+	}
+	for _, symbol := range slices.Sorted(maps.Keys(unknownSymbols)) {
+		l.logger.Warn("unknown pointer symbol", "symbol", symbol, "count", unknownSymbols[symbol])
+	}
+	if len(empty) > 0 {
+		for _, c := range byOffset {
+			c.relations = slices.DeleteFunc(c.relations, func(r semanticRelation) bool { return empty[r.target] })
+			c.unknownPointers = slices.DeleteFunc(c.unknownPointers, func(p unknownPointer) bool { return empty[p.target] })
+			for i := range c.words {
+				c.words[i].relations = slices.DeleteFunc(c.words[i].relations, func(r syntacticRelation) bool { return empty[r.target] })
+			}
+		}
+		h.emptySynsets = len(empty)
 	}
 
 	// now that we've built up the in ram database, lets' index it
-	for _, c := range byOffset {
+	for _, k := range keys {
+		c := byOffset[k]
+		if empty[c] {
+			continue
+		}
 		if len(c.words) == 0 {
 			return nil, fmt.Errorf("ERROR, internal consistency error -> cluster without words %v", c)
 		}
+		for _, w := range c.words {
+			for _, rel := range w.relations {
+				if int(rel.wordNumber) >= len(rel.target.words) {
+					return nil, fmt.Errorf("synset %s: bogus relation target (word %d of %s, which has %d words)", c.id(), rel.wordNumber+1, rel.target.id(), len(rel.target.words))
+				}
+			}
+		}
 
 		// add to the global slice of synsets (supports iteration)
 		h.db = append(h.db, c)
+		h.byID[c.id()] = c
+		c.derived = &h.derived
 
 		// now index all the strings
 		for _, w := range c.words {
@@ -344,31 +1176,228 @@ func New(dir string) (*Handle, error) {
 			v = append(v, c)
 			h.index[key] = v
 		}
+
+		// remember the tops of the noun and verb hierarchies
+		if c.pos == Noun || c.pos == Verb {
+			if !slices.ContainsFunc(c.relations, func(r semanticRelation) bool { return r.rel&generalizations != 0 }) {
+				h.roots[c.pos] = append(h.roots[c.pos], c)
+			}
+		}
+	}
+
+	if h.hasFrequencies {
+		for key, clusters := range h.index {
+			sortBySenseNumber(key, clusters)
+		}
+	}
+
+	h.derived.synsets = h.db
+	h.polysemy = countPolysemy(h.index)
+	h.domains = buildDomainIndex(h.db)
+
+	if opts.PhoneticIndex {
+		h.buildPhoneticIndex()
+	}
+	if opts.CollocationIndex {
+		h.buildCollocationIndex()
 	}
 
+	l.logger.Debug("loaded database", "synsets", len(h.db), "words", len(h.index), "duration", time.Since(l.start))
 	return &h, nil
 }
 
+// What to look up.  Every result points into the in-memory database, so
+// Related, Walk and the other traversals of a result only follow
+// pointers, without I/O or parsing, however many relations are followed;
+// PreloadRelations only saves callers that want the neighbourhood of
+// every result that walk.
 type Criteria struct {
 	Matching string
-	POS      PartOfSpeechList
+	// Additional surface forms to look up along with Matching (which may
+	// then be empty), e.g. inflectional variants of a query.  The results
+	// are the union of their meanings, each synset reported once, for the
+	// first form that found it.  POS filters the results of every form.
+	MatchingAny []string
+	POS         PartOfSpeechList
+	// Only the sense with this number (the word#pos#n convention), per part
+	// of speech.  Zero means all senses.  Sense numbers come from the sense
+	// index, so this is an error without index.sense.
+	SenseNumber int
+	// Skip the first Offset results and return at most Limit of the rest
+	// (all of them if Limit is zero), for pagination; see LookupPage
+	Offset, Limit int
+	// Attach to each result the synsets reachable from it through these
+	// relations in at most PreloadDepth steps (1 if zero), see
+	// Lookup.Preloaded.  Nothing is preloaded if zero.  Only the returned
+	// page is walked, but every result of it is, so the cost grows with
+	// the size of its neighbourhood: depth 1 is a Related call per result,
+	// while a deep Hyponym walk from a general noun visits much of the
+	// noun hierarchy (tens of thousands of synsets for "entity") and keeps
+	// a Lookup for each.
+	PreloadRelations Relation
+	PreloadDepth     int
 }
 
+// lower case in, trim it and collapse runs of whitespace (or of the
+// underscores wordnet joins collocations with) into single spaces
 func normalize(in string) string {
-	return strings.ToLower(strings.Join(strings.Fields(in), " "))
+	return strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(in, "_", " ")), " "))
 }
 
-// look up word clusters based on given criteria
+// undoes a Turkish lower casing of "I", wordnet's lemmas are English and
+// never contain a dotless i
+var dotlessI = strings.NewReplacer("ı", "i")
+
+// normalize a search string.  Without Options.CaseFold this is plain
+// lower casing, with it the language's lower casing rules are applied and
+// the result is case folded (so that "ß" matches "ss").
+func (h *Handle) normalizeQuery(in string) string {
+	if h.opts.CaseFold == language.Und {
+		return normalize(in)
+	}
+	// casers are stateful and can't be shared between goroutines
+	s := cases.Lower(h.opts.CaseFold).String(strings.Join(strings.Fields(strings.ReplaceAll(in, "_", " ")), " "))
+	return dotlessI.Replace(cases.Fold().String(s))
+}
+
+// look up word clusters based on given criteria.  Search strings are
+// matched case insensitively with surrounding whitespace trimmed, and
+// spaces or underscores separating the words of a collocation ("ice
+// cream", "ice_cream") are equivalent.  A word that isn't in the database
+// yields an empty slice and a nil error; an error is only returned for
+// invalid criteria, ErrEmptyQuery if there is nothing to search for.
+//
+// Results come grouped by part of speech (nouns, verbs, adjectives, then
+// adverbs) and within each in sense number order when the sense index is
+// loaded, senses it doesn't number last, otherwise in data file order.
+// With MatchingAny the results of each form follow those of the previous
+// one.  The order only depends on the data files.
 func (h *Handle) Lookup(crit Criteria) ([]Lookup, error) {
-	if crit.Matching == "" {
-		return nil, fmt.Errorf("empty string passed as criteria to lookup")
+	found, _, err := h.LookupPage(crit)
+	return found, err
+}
+
+// Lookup, also returning the number of results there are without
+// crit.Offset and crit.Limit, e.g. to show "1-5 of 23".
+func (h *Handle) LookupPage(crit Criteria) (results []Lookup, total int, err error) {
+	if h.indexOnly {
+		return nil, 0, ErrDataNotLoaded
+	}
+	if crit.SenseNumber < 0 {
+		return nil, 0, fmt.Errorf("invalid sense number %d", crit.SenseNumber)
+	}
+	if crit.SenseNumber != 0 && !h.hasFrequencies {
+		return nil, 0, fmt.Errorf("no sense numbers loaded, index.sense is needed for sense %d", crit.SenseNumber)
+	}
+	if crit.Offset < 0 || crit.Limit < 0 {
+		return nil, 0, fmt.Errorf("invalid page (offset %d, limit %d)", crit.Offset, crit.Limit)
+	}
+	if crit.PreloadDepth < 0 {
+		return nil, 0, fmt.Errorf("invalid preload depth %d", crit.PreloadDepth)
+	}
+
+	if h.lookupCache == nil {
+		return h.lookupPage(crit)
+	}
+
+	key := lookupCacheKey(crit)
+	if page, ok := h.lookupCache.get(key); ok {
+		return slices.Clone(page.results), page.total, nil
+	}
+	h.mu.RLock()
+	gen := h.morphGen
+	h.mu.RUnlock()
+
+	results, total, err = h.lookupPage(crit)
+	if err != nil {
+		return nil, 0, err
+	}
+	// results computed before a morphology change may be stale
+	h.mu.RLock()
+	if h.morphGen == gen {
+		h.lookupCache.put(key, lookupPage{slices.Clone(results), total})
+	}
+	h.mu.RUnlock()
+	return results, total, nil
+}
+
+// LookupPage without the cache
+func (h *Handle) lookupPage(crit Criteria) ([]Lookup, int, error) {
+	found, err := h.lookupAll(crit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if crit.SenseNumber != 0 {
+		found = selectSense(found, crit.SenseNumber)
 	}
 
-	searchStr := normalize(crit.Matching)
+	total := len(found)
+	found = found[min(crit.Offset, total):]
+	if crit.Limit > 0 && len(found) > crit.Limit {
+		found = found[:crit.Limit]
+	}
+	if crit.PreloadRelations != 0 {
+		for i := range found {
+			h.preload(&found[i], crit.PreloadRelations, max(crit.PreloadDepth, 1))
+		}
+	}
+	return found, total, nil
+}
+
+// attach to l what a breadth first walk through rels reaches from it
+func (h *Handle) preload(l *Lookup, rels Relation, depth int) {
+	reached := []Lookup{}
+	h.Walk(*l, WalkOptions{Relations: rels, MaxDepth: depth}, func(node Lookup, d int, _ Relation) bool {
+		if d > 0 {
+			reached = append(reached, node)
+		}
+		return true
+	})
+	l.preloaded = &reached
+}
+
+// the results of Lookup for all sense numbers
+func (h *Handle) lookupAll(crit Criteria) ([]Lookup, error) {
+	if len(crit.MatchingAny) == 0 {
+		if strings.TrimSpace(crit.Matching) == "" {
+			return nil, ErrEmptyQuery
+		}
+		return h.lookup(crit.Matching, crit.POS), nil
+	}
+
+	found := []Lookup{}
+	seen := map[*cluster]bool{}
+	empty := true
+	for _, m := range append([]string{crit.Matching}, crit.MatchingAny...) {
+		if strings.TrimSpace(m) == "" {
+			continue
+		}
+		empty = false
+		for _, l := range h.lookup(m, crit.POS) {
+			if !seen[l.cluster] {
+				seen[l.cluster] = true
+				found = append(found, l)
+			}
+		}
+	}
+	if empty {
+		return nil, ErrEmptyQuery
+	}
+
+	return found, nil
+}
+
+// the meanings of a single surface form
+func (h *Handle) lookup(matching string, posList PartOfSpeechList) []Lookup {
+	// the index of NewIndexOnly handles only holds stand ins for synsets
+	if h.indexOnly {
+		return nil
+	}
+	searchStr := h.normalizeQuery(matching)
 
 	// Check if searchStr is a known plural exception
 	// if so, replace it with the singular form
-	if val, ok := h.exceptions[searchStr]; ok {
+	if val, ok := h.exceptions[searchStr]; ok && !h.opts.DisableMorphExceptions {
 		searchStr = val
 	}
 
@@ -379,31 +1408,232 @@ func (h *Handle) Lookup(crit Criteria) ([]Lookup, error) {
 			if base := h.MorphWord(searchStr, pos); base != "" {
 				clusters = h.index[base]
 				if clusters != nil {
+					searchStr = base
 					break
 				}
 			}
 		}
 	}
 
+	matched := ""
+	if searchStr != normalize(matching) {
+		matched = searchStr
+	}
+
 	found := []Lookup{}
 
 	for _, c := range clusters {
-		if len(crit.POS) > 0 {
-			satisfied := slices.Contains(crit.POS, c.pos)
+		if len(posList) > 0 {
+			satisfied := slices.Contains(posList, c.pos)
 			if !satisfied {
 				continue
 			}
 		}
 
 		found = append(found, Lookup{
-			word:    crit.Matching,
+			word:    matching,
 			cluster: c,
+			matched: matched,
 		})
 	}
 
-	return found, nil
+	return found
+}
+
+// Whether word is an exact lemma of some pos synset, without applying
+// morphology (combine with MorphWord for inflected forms).  Much cheaper
+// than Lookup.
+func (h *Handle) Contains(word string, pos PartOfSpeech) bool {
+	return slices.ContainsFunc(h.index[h.normalizeQuery(word)], func(c *cluster) bool {
+		return c.pos == pos
+	})
+}
+
+// Whether word is an exact lemma of any synset, whatever its part of
+// speech
+func (h *Handle) ContainsAnyPOS(word string) bool {
+	return len(h.index[h.normalizeQuery(word)]) > 0
 }
 
+// Whether word, or a base form MorphWord finds for it as any part of
+// speech, is a lemma, for word games and spell checks.  Matching is case
+// insensitive.  Options.ValidWordsExcludeCollocations excludes lemmas
+// with spaces (or underscores) or hyphens and
+// Options.ValidWordsExcludeProperNouns those that are capitalized in
+// every synset listing them; everything else WordNet lists is accepted,
+// including abbreviations ("cm") and lemmas with digits ("4th").
+func (h *Handle) IsValidWord(word string) bool {
+	key := h.normalizeQuery(word)
+	if h.validWord(key) {
+		return true
+	}
+	for _, pos := range []PartOfSpeech{Noun, Verb, Adjective, Adverb} {
+		if base := h.MorphWord(key, pos); base != "" && h.validWord(base) {
+			return true
+		}
+	}
+	return false
+}
+
+// whether the normalized word is a lemma IsValidWord accepts
+func (h *Handle) validWord(word string) bool {
+	if h.opts.ValidWordsExcludeCollocations && strings.ContainsAny(word, " -") {
+		return false
+	}
+	for _, c := range h.index[word] {
+		if !h.opts.ValidWordsExcludeProperNouns {
+			return true
+		}
+		for _, m := range c.words {
+			if m.word == strings.ToLower(m.word) && normalize(m.word) == word {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// The pos synsets having both a and b as members, i.e. the senses in
+// which they are synonyms, e.g. "big" and "large".  Words are matched as
+// lemmas (no morphology), results are in data file order and searched
+// for as a.  Nil for handles created with NewIndexOnly.
+func (h *Handle) SharedSynsets(a, b string, pos PartOfSpeech) []Lookup {
+	if h.indexOnly {
+		return nil
+	}
+	withB := map[*cluster]bool{}
+	for _, c := range h.index[h.normalizeQuery(b)] {
+		withB[c] = true
+	}
+
+	found := []Lookup{}
+	for _, c := range h.index[h.normalizeQuery(a)] {
+		if c.pos == pos && withB[c] {
+			found = append(found, Lookup{word: a, cluster: c})
+			// only once if a is in c under several spellings
+			delete(withB, c)
+		}
+	}
+	return found
+}
+
+// The pointer symbols (e.g. "@" for hypernyms, "%p" for part meronyms) of
+// every relation word has in any of its pos synsets, sorted and without
+// duplicates.  This is the pointer list of the word's entry in the
+// wordnet index files, and a cheap way to check whether a word takes
+// part in a kind of relation at all before traversing its senses.
+func (h *Handle) PointerSymbols(word string, pos PartOfSpeech) []string {
+	found, err := h.Lookup(Criteria{Matching: word, POS: PartOfSpeechList{pos}})
+	if err != nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, f := range found {
+		for _, rel := range f.cluster.relations {
+			seen[relationSymbols[rel.rel]] = true
+		}
+		if m := f.member(); m != nil {
+			for _, rel := range m.relations {
+				seen[relationSymbols[rel.rel]] = true
+			}
+		}
+	}
+
+	symbols := make([]string, 0, len(seen))
+	for s := range seen {
+		symbols = append(symbols, s)
+	}
+	slices.Sort(symbols)
+	return symbols
+}
+
+// Find the synset with the given id, as returned by SynsetID (e.g.
+// "02086723-n").  Adjective satellites may use either "a" or "s".  Ids
+// are offsets, see LookupByOffset for their pitfalls.
+func (h *Handle) LookupByID(id string) (Lookup, error) {
+	if h.indexOnly {
+		return Lookup{}, ErrDataNotLoaded
+	}
+	offset, letter, ok := strings.Cut(id, "-")
+	if !ok || len(offset) != 8 || len(letter) != 1 {
+		return Lookup{}, fmt.Errorf("malformed synset id %q", id)
+	}
+	l := lexable(letter)
+	pos, err := l.lexPOS()
+	if err != nil {
+		return Lookup{}, fmt.Errorf("malformed synset id %q: %s", id, err)
+	}
+
+	c, ok := h.byID[offset+"-"+pos.letter()]
+	if !ok {
+		return Lookup{}, fmt.Errorf("%w: no synset with id %q", ErrSynsetNotFound, id)
+	}
+	return Lookup{
+		word:    c.words[0].word,
+		cluster: c,
+	}, nil
+}
+
+// Find the synset starting at the given byte offset of the data file for
+// pos, as reported by other wordnet tools.  Offsets are only meaningful
+// for the WordNet version they were taken from (3.1 moved most synsets of
+// 3.0): one that doesn't start a record of the loaded data gives
+// ErrSynsetNotFound, but one that happens to start another synset's
+// record can't be told apart.
+func (h *Handle) LookupByOffset(pos PartOfSpeech, offset int) (Lookup, error) {
+	if h.indexOnly {
+		return Lookup{}, ErrDataNotLoaded
+	}
+	if offset < 0 || offset > 99999999 {
+		return Lookup{}, fmt.Errorf("invalid offset %d", offset)
+	}
+	c, ok := h.byID[fmt.Sprintf("%08d-%s", offset, pos.letter())]
+	if !ok {
+		return Lookup{}, fmt.Errorf("%w: no %s synset starts at offset %d", ErrSynsetNotFound, pos, offset)
+	}
+	return Lookup{
+		word:    c.words[0].word,
+		cluster: c,
+	}, nil
+}
+
+// Find the single meaning of word (as pos) whose gloss mentions
+// glossKeyword, e.g. ("bank", Noun, "river") for the river bank.  The
+// keyword is matched case insensitively.  It is an error if no sense or
+// more than one sense matches.
+func (h *Handle) LookupSpecific(word string, pos PartOfSpeech, glossKeyword string) (Lookup, error) {
+	found, err := h.Lookup(Criteria{Matching: word, POS: []PartOfSpeech{pos}})
+	if err != nil {
+		return Lookup{}, err
+	}
+
+	if len(found) == 0 {
+		return Lookup{}, fmt.Errorf("%w: %q (%s)", ErrWordNotFound, word, pos)
+	}
+
+	keyword := strings.ToLower(glossKeyword)
+	var matches []Lookup
+	for _, f := range found {
+		if strings.Contains(strings.ToLower(f.cluster.gloss), keyword) {
+			matches = append(matches, f)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Lookup{}, fmt.Errorf("no %s sense of %q mentions %q", pos, word, glossKeyword)
+	case 1:
+		return matches[0], nil
+	}
+	return Lookup{}, fmt.Errorf("%d %s senses of %q mention %q", len(matches), pos, word, glossKeyword)
+}
+
+// Call cb for every synset of the given parts of speech (all if empty) in
+// data file order, stopping at the first error, which is returned.  The
+// Lookups passed are references into the database that copy nothing, so
+// a pass that only counts synsets or reads their members costs no more
+// than the accessors it calls; relations are only built by Related.
 func (h *Handle) Iterate(pos PartOfSpeechList, cb func(Lookup) error) error {
 	for _, c := range h.db {
 		if !pos.Empty() && !pos.Contains(c.pos) {
@@ -423,6 +1653,102 @@ func (h *Handle) Iterate(pos PartOfSpeechList, cb func(Lookup) error) error {
 	return nil
 }
 
+// Call fn for every pair of words of pos that are direct antonyms, e.g.
+// ("good", "bad") and ("hot", "cold"), in data file order.  A pair is
+// reported once, whichever way round and however many senses it holds in.
+// Iteration stops at the first error fn returns, which is passed on.
+func (h *Handle) AntonymPairs(pos PartOfSpeech, fn func(a, b string) error) error {
+	seen := map[[2]string]bool{}
+	for _, c := range h.db {
+		if c.pos != pos {
+			continue
+		}
+		for _, m := range c.words {
+			for _, rel := range m.relations {
+				if rel.rel != Antonym {
+					continue
+				}
+				a, b := m.word, rel.target.words[rel.wordNumber].word
+				if seen[[2]string{a, b}] || seen[[2]string{b, a}] {
+					continue
+				}
+				seen[[2]string{a, b}] = true
+				if err := fn(a, b); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// All synsets of pos with between min and max members (inclusive), e.g.
+// min = 3 for good thesaurus examples or min = max = 1 for unambiguous
+// definitions.  A max of zero or less means no upper bound.
+func (h *Handle) SynsetsWithSynonymCount(pos PartOfSpeech, min, max int) (found []Lookup) {
+	_ = h.Iterate(PartOfSpeechList{pos}, func(l Lookup) error {
+		if n := len(l.cluster.words); n >= min && (max <= 0 || n <= max) {
+			found = append(found, l)
+		}
+		return nil
+	})
+	return found
+}
+
+// Like Iterate, but cb is invoked from a pool of workers goroutines
+// (GOMAXPROCS if workers <= 0) and so must be safe to call concurrently.
+// Synsets are visited in no particular order.  The first error returned
+// by cb stops the iteration and is returned once all workers are done.
+func (h *Handle) IterateParallel(pos PartOfSpeechList, workers int, cb func(Lookup) error) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	work := make(chan *cluster, workers)
+	done := make(chan struct{})
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range work {
+				err := cb(Lookup{
+					word:    c.words[0].word,
+					cluster: c,
+				})
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						close(done)
+					})
+					return
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, c := range h.db {
+		if !pos.Empty() && !pos.Contains(c.pos) {
+			continue
+		}
+		select {
+		case work <- c:
+		case <-done:
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	return firstErr
+}
+
 // wordbase removes a suffix from 'word' if it matches suffixes[ender], then appends plugalEndings[ender].
 func wordbase(word string, ender int) string {
 	copy := word
@@ -436,11 +1762,178 @@ func wordbase(word string, ender int) string {
 	return copy
 }
 
+// Teach morphology an irregular form that the wordnet exception lists
+// lack, e.g. a jargon plural.  Exceptions added this way take priority
+// over the built in rules in MorphWord.  Safe to call while other
+// goroutines are using the handle.
+func (h *Handle) AddException(pos PartOfSpeech, surface, base string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.userExceptions == nil {
+		h.userExceptions = map[PartOfSpeech]map[string]string{}
+	}
+	if h.userExceptions[pos] == nil {
+		h.userExceptions[pos] = map[string]string{}
+	}
+	h.userExceptions[pos][normalize(surface)] = normalize(base)
+	h.morphCache.purge()
+	h.lookupCache.purge()
+	h.morphGen++
+}
+
+// A suffix rule added with AddSuffixRule
+type suffixRule struct {
+	suffix, ending string
+}
+
+// word with the rule's suffix replaced by its ending, if it has the
+// suffix and something is left before it
+func (r suffixRule) apply(word string) (string, bool) {
+	stem, ok := strings.CutSuffix(word, r.suffix)
+	if !ok || stem == "" {
+		return "", false
+	}
+	return stem + r.ending, stem+r.ending != word
+}
+
+// Teach morphology a suffix rule beyond WordNet's inflectional ones,
+// replacing suffix with ending, e.g. ("ness", "") to reduce "fullness" to
+// "full" or ("iness", "y") for "happiness".  A rule only applies if the
+// result is a lemma of any part of speech, which suits derivations that
+// change it.  Rules are tried in the order they were added, after the
+// exception lists and before the built in rules, and aren't subject to
+// their restrictions (e.g. on nouns ending in "ss").  Safe to call while
+// other goroutines are using the handle.
+func (h *Handle) AddSuffixRule(pos PartOfSpeech, suffix, ending string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.userSuffixes == nil {
+		h.userSuffixes = map[PartOfSpeech][]suffixRule{}
+	}
+	h.userSuffixes[pos] = append(h.userSuffixes[pos], suffixRule{normalize(suffix), normalize(ending)})
+	h.morphCache.purge()
+	h.lookupCache.purge()
+	h.morphGen++
+}
+
+// Whether MorphWord finds a base form of word as pos other than word
+// itself
+func (h *Handle) CanMorph(word string, pos PartOfSpeech) bool {
+	return h.MorphWord(word, pos) != ""
+}
+
+// the base form registered for word through AddException, if any.  The
+// caller must hold h.mu.
+func (h *Handle) userException(word string, pos PartOfSpeech) (string, bool) {
+	base, ok := h.userExceptions[pos][word]
+	return base, ok
+}
+
+type morphKey struct {
+	word string
+	pos  PartOfSpeech
+}
+
 // Try to find all possible baseforms (lemmas) of individual word in POS.
+// Exceptions added with AddException are consulted first, then the
+// pos exception list (e.g. verb.exc), then rules added with
+// AddSuffixRule, then WordNet's inflectional suffix rules.  Results are
+// cached when the handle was created with
+// Options.MorphCacheSize.
+//
+// An empty result means word has no base form other than itself.  This
+// is intentional for adverbs, which WordNet doesn't inflect, and for
+// nouns ending in "ss" ("glass", "fullness") or of two letters or less,
+// which the built in rules leave alone as they are never regular
+// plurals.  The built in rules are inflectional only, so derivations such
+// as "fullness" from "full" need a rule of their own.  Noun possessives
+// are reduced to the noun ("children's" gives "child"), and hyphenated
+// words that aren't lemmas are also tried written as one word or as two
+// ("wild-life" gives "wildlife").
 func (h *Handle) MorphWord(word string, pos PartOfSpeech) string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.cachedMorphWord(word, pos)
+}
+
+// morphWord through the morphology cache, the caller holds h.mu
+func (h *Handle) cachedMorphWord(word string, pos PartOfSpeech) string {
+	key := morphKey{word, pos}
+	if base, ok := h.morphCache.get(key); ok {
+		return base
+	}
+	base := h.morphWord(word, pos)
+	h.morphCache.put(key, base)
+	return base
+}
+
+// whether the normalized word is a lemma of pos
+func (h *Handle) isLemma(word string, pos PartOfSpeech) bool {
+	return slices.ContainsFunc(h.index[word], func(c *cluster) bool {
+		return c.pos == pos
+	})
+}
+
+// the noun a possessive is formed from, "mother" for "mother's" and
+// "dogs" for "dogs'"
+func cutPossessive(word string) (string, bool) {
+	for _, suffix := range []string{"'s", "’s"} {
+		if stem, ok := strings.CutSuffix(word, suffix); ok && stem != "" {
+			return stem, true
+		}
+	}
+	for _, suffix := range []string{"s'", "s’"} {
+		if strings.HasSuffix(word, suffix) && len(word) > len(suffix) {
+			return strings.TrimRight(word, "'’"), true
+		}
+	}
+	return "", false
+}
+
+func (h *Handle) morphWord(word string, pos PartOfSpeech) string {
+	if base, ok := h.userException(word, pos); ok {
+		return base
+	}
+
+	// irregular forms from the wordnet exception lists
+	if bases := h.excByPOS[pos][word]; len(bases) > 0 && !h.opts.DisableMorphExceptions {
+		return bases[0]
+	}
+
+	for _, rule := range h.userSuffixes[pos] {
+		if base, ok := rule.apply(word); ok && h.index[base] != nil {
+			return base
+		}
+	}
+
+	// possessives, e.g. "mother's", "children's" and "dogs'"
+	if stem, ok := cutPossessive(word); ok && pos == Noun {
+		if h.isLemma(stem, pos) {
+			return stem
+		}
+		return h.morphWord(stem, pos)
+	}
+
+	// hyphenated forms may also be written as one word or as two, e.g.
+	// "wild-life" and "ice-creams"
+	if strings.Contains(word, "-") && !h.isLemma(word, pos) {
+		for _, sep := range []string{"", " "} {
+			joined := strings.ReplaceAll(word, "-", sep)
+			if h.isLemma(joined, pos) {
+				return joined
+			}
+			if base := h.morphWord(joined, pos); base != "" {
+				return base
+			}
+		}
+	}
+
 	switch pos {
 	case Adverb:
-		// Adverbs are not inflected in WordNet
+		// Adverbs are not inflected in WordNet, apart from the few
+		// irregular comparatives in adv.exc
 		return ""
 	case Noun:
 		if strings.HasSuffix(word, "ful") {
@@ -450,6 +1943,10 @@ func (h *Handle) MorphWord(word string, pos PartOfSpeech) string {
 		}
 	}
 
+	// e.g. NoPOS, which has no detachment rules
+	if int(pos) >= len(offsets) {
+		return ""
+	}
 	offset := offsets[int(pos)]
 	count := counts[int(pos)]
 
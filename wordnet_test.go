@@ -1,10 +1,25 @@
 package wnram
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"os"
 	"path"
+	"path/filepath"
 	"runtime"
 	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+
+	"golang.org/x/text/language"
 )
 
 const PathToWordnetDataFiles = "./data"
@@ -112,6 +127,27 @@ func TestLemma(t *testing.T) {
 	if found[0].Lemma() != "amazing" {
 		t.Errorf("incorrect lemma for awesome (%s)", found[0].Lemma())
 	}
+	if found[0].Word() != "awesome" {
+		t.Errorf("incorrect word for awesome (%s)", found[0].Word())
+	}
+
+	// every member of a synset gives the same lemma, the first member
+	for _, member := range found[0].Synonyms() {
+		other, err := wnInstance.Lookup(Criteria{Matching: member, POS: []PartOfSpeech{Adjective}})
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		for _, o := range other {
+			if o.SynsetID() == found[0].SynsetID() && o.Lemma() != "amazing" {
+				t.Errorf("incorrect lemma when searching for %s (%s)", member, o.Lemma())
+			}
+		}
+	}
+
+	var zero Lookup
+	if zero.Lemma() != "" {
+		t.Errorf("expected no lemma for the zero Lookup, got %q", zero.Lemma())
+	}
 }
 
 func setContains(haystack, needles []string) bool {
@@ -143,6 +179,203 @@ func TestSynonyms(t *testing.T) {
 	}
 }
 
+func TestSynonymsIncludeQueryWord(t *testing.T) {
+	found, err := wnInstance.Lookup(Criteria{Matching: "yummy", POS: PartOfSpeechList{Adjective}})
+	if err != nil || len(found) == 0 {
+		t.Fatalf("can't find yummy: %v", err)
+	}
+	if syns := found[0].Synonyms(); !slices.Contains(syns, "yummy") {
+		t.Errorf("Synonyms should include the query word, got %v", syns)
+	}
+
+	// inflected queries contribute their lemma
+	found, err = wnInstance.Lookup(Criteria{Matching: "geese", POS: PartOfSpeechList{Noun}})
+	if err != nil || len(found) == 0 {
+		t.Fatalf("can't find geese: %v", err)
+	}
+	for _, f := range found {
+		if syns := f.Synonyms(); !slices.Contains(syns, "goose") || slices.Contains(syns, "geese") {
+			t.Errorf("%s: unexpected members %v", f.SynsetID(), syns)
+		}
+	}
+}
+
+func TestIsSatellite(t *testing.T) {
+	found, err := wnInstance.Lookup(Criteria{Matching: "yummy", POS: PartOfSpeechList{Adjective}})
+	if err != nil || len(found) != 1 {
+		t.Fatalf("can't find yummy: %v", err)
+	}
+	yummy := found[0]
+	if !yummy.IsSatellite() || yummy.POS() != Adjective {
+		t.Errorf("yummy should be a satellite adjective")
+	}
+	heads := yummy.Related(SimilarTo)
+	if len(heads) == 0 {
+		t.Fatalf("yummy has no head synset")
+	}
+	for _, h := range heads {
+		if h.IsSatellite() {
+			t.Errorf("head %s of yummy is a satellite", h.SynsetID())
+		}
+	}
+	if dog := firstSense(t, wnInstance, "dog", Noun); dog.IsSatellite() {
+		t.Errorf("nouns aren't satellites")
+	}
+}
+
+func TestSyntacticMarker(t *testing.T) {
+	for word, marker := range map[string]string{"galore": "ip", "ready to hand": "p", "outback": "a"} {
+		found, err := wnInstance.Lookup(Criteria{Matching: word, POS: PartOfSpeechList{Adjective}})
+		if err != nil || len(found) == 0 {
+			t.Errorf("can't find %q: %v", word, err)
+			continue
+		}
+		l := found[0]
+		if got := l.SyntacticMarker(); got != marker {
+			t.Errorf("SyntacticMarker(%q) = %q; want %q", word, got, marker)
+		}
+		for _, syn := range l.Synonyms() {
+			if strings.Contains(syn, "(") {
+				t.Errorf("marker left in member %q", syn)
+			}
+		}
+	}
+	if good := firstSense(t, wnInstance, "good", Adjective); good.SyntacticMarker() != "" {
+		t.Errorf("unexpected marker %q on good", good.SyntacticMarker())
+	}
+	if base, marker := splitMarker("(p)"); base != "(p)" || marker != "" {
+		t.Errorf("splitMarker should leave a bare marker alone, got %q, %q", base, marker)
+	}
+}
+
+func TestRelatedAreFullLookups(t *testing.T) {
+	dog := specificSense(t, "dog", Noun, "domesticated")
+	hypernyms := dog.Related(Hypernym)
+	if len(hypernyms) == 0 {
+		t.Fatalf("dog has no hypernyms")
+	}
+	i := slices.IndexFunc(hypernyms, func(l Lookup) bool { return l.Lemma() == "canine" })
+	if i < 0 {
+		t.Fatalf("canine isn't a hypernym of dog: %v", hypernyms)
+	}
+	canine := hypernyms[i]
+	if canine.Lemma() != "canine" || !strings.Contains(canine.Gloss(), "fissiped mammals") {
+		t.Errorf("unexpected hypernym %s: %s", canine.Lemma(), canine.Gloss())
+	}
+	if canine.SynsetID() == "" || canine.SynsetID() == dog.SynsetID() {
+		t.Errorf("unexpected synset id %q", canine.SynsetID())
+	}
+	if back, err := wnInstance.LookupByID(canine.SynsetID()); err != nil || !back.Equal(canine) {
+		t.Errorf("can't find the hypernym by its id: %v", err)
+	}
+	// and traversal continues from it
+	if up := canine.Related(Hypernym); !slices.ContainsFunc(up, func(l Lookup) bool { return l.Lemma() == "carnivore" }) {
+		t.Errorf("unexpected hypernyms of canine: %v", up)
+	}
+	if down := canine.Related(Hyponym); !slices.ContainsFunc(down, dog.Equal) {
+		t.Errorf("dog should be a hyponym of canine")
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	dog := specificSense(t, "dog", Noun, "domesticated")
+	want := "n" + strings.TrimSuffix(dog.SynsetID(), "-n") + "{dog,domestic_dog,Canis_familiaris}"
+	if got := dog.Canonical(); got != want {
+		t.Errorf("Canonical() = %q; want %q", got, want)
+	}
+	byOtherWord := specificSense(t, "Canis familiaris", Noun, "domesticated")
+	if byOtherWord.Canonical() != dog.Canonical() {
+		t.Errorf("canonical form depends on the query: %q", byOtherWord.Canonical())
+	}
+	var zero Lookup
+	if got := zero.Canonical(); got != "" {
+		t.Errorf("Canonical() of the zero Lookup = %q", got)
+	}
+}
+
+func TestLookupPage(t *testing.T) {
+	all, err := wnInstance.Lookup(Criteria{Matching: "run"})
+	if err != nil || len(all) < 10 {
+		t.Fatalf("expected many senses of run, got %d, %v", len(all), err)
+	}
+
+	page, total, err := wnInstance.LookupPage(Criteria{Matching: "run", Offset: 5, Limit: 5})
+	if err != nil || total != len(all) || len(page) != 5 {
+		t.Fatalf("LookupPage = %d results of %d, %v; want 5 of %d", len(page), total, err, len(all))
+	}
+	for i := range page {
+		if !page[i].Equal(all[i+5]) {
+			t.Errorf("result %d of the page isn't result %d of all", i, i+5)
+		}
+	}
+
+	last, total, _ := wnInstance.LookupPage(Criteria{Matching: "run", Offset: len(all) - 2, Limit: 5})
+	if len(last) != 2 || total != len(all) {
+		t.Errorf("last page has %d results of %d", len(last), total)
+	}
+	past, total, err := wnInstance.LookupPage(Criteria{Matching: "run", Offset: 1000})
+	if err != nil || len(past) != 0 || total != len(all) {
+		t.Errorf("page past the end: %d results of %d, %v", len(past), total, err)
+	}
+	if limited, _ := wnInstance.Lookup(Criteria{Matching: "run", Limit: 3}); len(limited) != 3 {
+		t.Errorf("Lookup ignores Limit, got %d", len(limited))
+	}
+	if _, _, err := wnInstance.LookupPage(Criteria{Matching: "run", Offset: -1}); err == nil {
+		t.Errorf("expected an error for a negative offset")
+	}
+}
+
+func TestPreloadRelations(t *testing.T) {
+	crit := Criteria{Matching: "dog", POS: PartOfSpeechList{Noun}, Limit: 1}
+	plain, err := wnInstance.Lookup(crit)
+	if err != nil || len(plain) != 1 {
+		t.Fatalf("expected a sense of dog, got %d, %v", len(plain), err)
+	}
+	if got := plain[0].Preloaded(); got != nil {
+		t.Errorf("Preloaded() without PreloadRelations = %v", got)
+	}
+
+	crit.PreloadRelations = Hypernym
+	found, err := wnInstance.Lookup(crit)
+	if err != nil || len(found) != 1 {
+		t.Fatalf("expected a sense of dog, got %d, %v", len(found), err)
+	}
+	// the same synsets as Related, in walk rather than SynsetID order
+	got, want := lemmas(found[0].Preloaded()), lemmas(plain[0].Related(Hypernym))
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("preloaded hypernyms at depth 1 = %v, want %v", got, want)
+	}
+
+	crit.PreloadDepth = 2
+	found, _ = wnInstance.Lookup(crit)
+	want = nil
+	wnInstance.Walk(plain[0], WalkOptions{Relations: Hypernym, MaxDepth: 2}, func(node Lookup, depth int, _ Relation) bool {
+		if depth > 0 {
+			want = append(want, node.Lemma())
+		}
+		return true
+	})
+	got = lemmas(found[0].Preloaded())
+	if !slices.Equal(got, want) || !slices.Contains(got, "carnivore") {
+		t.Errorf("preloaded hypernyms at depth 2 = %v, want %v (with carnivore)", got, want)
+	}
+
+	crit.PreloadDepth = -1
+	if _, err := wnInstance.Lookup(crit); err == nil {
+		t.Error("expected an error for a negative preload depth")
+	}
+}
+
+// the lemmas of results, in order
+func lemmas(results []Lookup) (words []string) {
+	for _, r := range results {
+		words = append(words, r.Lemma())
+	}
+	return words
+}
+
 func TestAntonyms(t *testing.T) {
 	found, err := wnInstance.Lookup(Criteria{Matching: "good", POS: []PartOfSpeech{Adjective}})
 	if err != nil {
@@ -162,6 +395,32 @@ func TestAntonyms(t *testing.T) {
 	}
 }
 
+func TestAntonymsPerSense(t *testing.T) {
+	// antonymy is recorded per sense: light as in brightness is opposed to
+	// dark, light as in weight to heavy, and the senses don't share them
+	bright := specificSense(t, "light", Adjective, "emitting light")
+	weightless := specificSense(t, "light", Adjective, "little physical weight")
+	for _, c := range []struct {
+		sense Lookup
+		want  string
+	}{{bright, "dark"}, {weightless, "heavy"}} {
+		if got := c.sense.RelatedWords(Antonym); !slices.Equal(got, []string{c.want}) {
+			t.Errorf("antonyms of light (%s) = %v; want [%s]", c.sense.Gloss(), got, c.want)
+		}
+	}
+
+	// the same holds for good, whose antonyms TestAntonyms collects over
+	// all senses
+	desirable := specificSense(t, "good", Adjective, "desirable or positive")
+	moral := specificSense(t, "good", Adjective, "morally admirable")
+	if got := desirable.RelatedWords(Antonym); !slices.Equal(got, []string{"bad"}) {
+		t.Errorf("antonyms of good (%s) = %v; want [bad]", desirable.Gloss(), got)
+	}
+	if got := moral.RelatedWords(Antonym); !slices.Equal(got, []string{"evil"}) {
+		t.Errorf("antonyms of good (%s) = %v; want [evil]", moral.Gloss(), got)
+	}
+}
+
 func TestHypernyms(t *testing.T) {
 	found, err := wnInstance.Lookup(Criteria{Matching: "jab", POS: []PartOfSpeech{Noun}})
 	if err != nil {
@@ -278,3 +537,940 @@ func TestMorphword(t *testing.T) {
 		}
 	}
 }
+
+// writeDataDir writes the given files into a fresh temporary directory
+// and returns its path.  Useful for building tiny synthetic databases.
+func writeDataDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644); err != nil {
+			t.Fatalf("can't write %s: %s", name, err)
+		}
+	}
+	return dir
+}
+
+func TestNewDefault(t *testing.T) {
+	defer func(dirs []string) { defaultDataDirs = dirs }(defaultDataDirs)
+	defaultDataDirs = []string{filepath.Join(t.TempDir(), "missing")}
+
+	home := t.TempDir()
+	if err := os.Mkdir(filepath.Join(home, "dict"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, "dict", "data.noun"), []byte("00000001 05 n 01 dog 0 000 | a canine\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	searchDir := writeDataDir(t, map[string]string{"data.noun": "00000001 05 n 01 cat 0 000 | a feline\n"})
+
+	t.Setenv("WNHOME", home)
+	t.Setenv("WNSEARCHDIR", searchDir)
+	h, err := NewDefault()
+	if err != nil || !h.Contains("cat", Noun) {
+		t.Fatalf("expected $WNSEARCHDIR to be loaded, got %v", err)
+	}
+
+	t.Setenv("WNSEARCHDIR", "")
+	h, err = NewDefault()
+	if err != nil || !h.Contains("dog", Noun) {
+		t.Fatalf("expected $WNHOME/dict to be loaded, got %v", err)
+	}
+
+	t.Setenv("WNHOME", t.TempDir())
+	if _, err := NewDefault(); err == nil || !strings.Contains(err.Error(), defaultDataDirs[0]) {
+		t.Errorf("expected an error listing the searched directories, got %v", err)
+	}
+}
+
+func TestNewFromOpener(t *testing.T) {
+	dir := sourceCodeRelPath(PathToWordnetDataFiles)
+	var mu sync.Mutex
+	requested := []string{}
+	h, err := NewFromOpener(func(name string) (io.ReadCloser, error) {
+		mu.Lock()
+		requested = append(requested, name)
+		mu.Unlock()
+		return os.Open(filepath.Join(dir, name))
+	})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	slices.Sort(requested)
+	if !slices.Equal(requested, openerFiles) {
+		t.Errorf("requested %v, want %v", requested, openerFiles)
+	}
+	if got, want := h.Stats(), wnInstance.Stats(); got.Synsets != want.Synsets || got.Words != want.Words {
+		t.Errorf("loaded %d synsets and %d words, want %d and %d", got.Synsets, got.Words, want.Synsets, want.Words)
+	}
+	if found, _ := h.Lookup(Criteria{Matching: "wolves"}); len(found) == 0 {
+		t.Errorf("exceptions weren't loaded")
+	}
+
+	broken := errors.New("connection reset")
+	_, err = NewFromOpener(func(name string) (io.ReadCloser, error) {
+		if name == "data.verb" {
+			return nil, broken
+		}
+		return nil, fs.ErrNotExist
+	})
+	if !errors.Is(err, broken) {
+		t.Errorf("expected the opener's error, got %v", err)
+	}
+}
+
+func TestIndependentHandles(t *testing.T) {
+	dirA := writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 01 widget 0 000 | a small gadget (version a)\n",
+		"noun.exc":  "widgeta widget\n",
+	})
+	dirB := writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 01 widget 0 000 | a small gadget (version b)\n" +
+			"00000002 03 n 01 gizmo 0 000 | only present in version b\n",
+	})
+
+	a, err := New(dirA)
+	if err != nil {
+		t.Fatalf("can't load %s: %s", dirA, err)
+	}
+	b, err := New(dirB)
+	if err != nil {
+		t.Fatalf("can't load %s: %s", dirB, err)
+	}
+
+	foundA, _ := a.Lookup(Criteria{Matching: "widget"})
+	foundB, _ := b.Lookup(Criteria{Matching: "widget"})
+	if len(foundA) != 1 || len(foundB) != 1 {
+		t.Fatalf("expected one result from each handle, got %d and %d", len(foundA), len(foundB))
+	}
+	if foundA[0].Gloss() == foundB[0].Gloss() {
+		t.Errorf("handles share data: both glosses are %q", foundA[0].Gloss())
+	}
+
+	if found, _ := a.Lookup(Criteria{Matching: "gizmo"}); len(found) != 0 {
+		t.Errorf("gizmo leaked from second handle into first")
+	}
+	if found, _ := b.Lookup(Criteria{Matching: "widgeta"}); len(found) != 0 {
+		t.Errorf("exceptions leaked from first handle into second")
+	}
+	if found, _ := wnInstance.Lookup(Criteria{Matching: "gizmo", POS: []PartOfSpeech{Noun}}); len(found) == 0 {
+		t.Errorf("full database should still know gizmo")
+	}
+}
+
+func TestDumpTo(t *testing.T) {
+	found, err := wnInstance.Lookup(Criteria{Matching: "jab", POS: []PartOfSpeech{Noun}})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(found) == 0 {
+		t.Fatalf("no results for jab")
+	}
+
+	var buf bytes.Buffer
+	found[0].DumpTo(&buf)
+	out := buf.String()
+
+	for _, want := range []string{"Word: \"jab\" (noun)", "Synset: " + found[0].cluster.id(), "hypernym: ", "| "} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dump output missing %q:\n%s", want, out)
+		}
+	}
+	if out != found[0].DumpStr() {
+		t.Errorf("DumpTo and DumpStr disagree")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	dogs, _ := wnInstance.Lookup(Criteria{Matching: "dog", POS: []PartOfSpeech{Noun}})
+	domestic, _ := wnInstance.Lookup(Criteria{Matching: "domestic dog", POS: []PartOfSpeech{Noun}})
+	if len(dogs) == 0 || len(domestic) != 1 {
+		t.Fatalf("unexpected results: %d dog senses, %d domestic dog senses", len(dogs), len(domestic))
+	}
+
+	matches := 0
+	for _, d := range dogs {
+		if d.Equal(domestic[0]) {
+			matches++
+			if d.SynsetID() != domestic[0].SynsetID() {
+				t.Errorf("equal synsets have different ids: %s != %s", d.SynsetID(), domestic[0].SynsetID())
+			}
+		}
+	}
+	if matches != 1 {
+		t.Errorf("expected exactly one dog sense equal to domestic dog, got %d", matches)
+	}
+	if dogs[0].Equal(dogs[1]) {
+		t.Errorf("distinct senses of dog compare equal")
+	}
+	if id := domestic[0].SynsetID(); len(id) != 10 || !strings.HasSuffix(id, "-n") {
+		t.Errorf("malformed synset id %q", id)
+	}
+}
+
+func TestRelationCount(t *testing.T) {
+	found, err := wnInstance.Lookup(Criteria{Matching: "food", POS: []PartOfSpeech{Noun}})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	for _, f := range found {
+		for _, r := range []Relation{Hyponym, Hypernym, Hyponym | Hypernym, DerivationallyRelatedForm} {
+			if got, want := f.RelationCount(r), len(f.Related(r)); got != want {
+				t.Errorf("RelationCount(%s) for %s = %d; want %d", r.name(), f.SynsetID(), got, want)
+			}
+		}
+	}
+
+	data, err := json.Marshal(found[0])
+	if err != nil {
+		t.Fatalf("can't marshal: %s", err)
+	}
+	var decoded struct {
+		ID             string         `json:"id"`
+		RelationCounts map[string]int `json:"relation_counts"`
+		Richness       int            `json:"richness"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("can't unmarshal %s: %s", data, err)
+	}
+	if decoded.ID != found[0].SynsetID() || decoded.RelationCounts["hyponym"] != found[0].RelationCount(Hyponym) || decoded.Richness != found[0].SemanticRichness() {
+		t.Errorf("unexpected json output: %s", data)
+	}
+}
+
+func TestSemanticRichness(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.adj": "00000001 00 a 02 big 0 large 0 003 & 00000003 a 0000 ! 00000002 a 0101 ! 00000002 a 0201 | above average in size\n" +
+			"00000002 00 a 01 small 0 002 ! 00000001 a 0101 ! 00000001 a 0102 | below average in size\n" +
+			"00000003 00 s 01 huge 0 001 & 00000001 a 0000 | extremely large\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	for word, want := range map[string]int{"big": 3, "large": 3, "small": 2, "huge": 1} {
+		l := firstSense(t, h, word, Adjective)
+		if got := l.SemanticRichness(); got != want {
+			t.Errorf("SemanticRichness(%s) = %d; want %d", word, got, want)
+		}
+	}
+	var zero Lookup
+	if got := zero.SemanticRichness(); got != 0 {
+		t.Errorf("expected no relations for the zero Lookup, got %d", got)
+	}
+
+	// the most studied sense of dog has more relations than a rare one
+	dog := specificSense(t, "dog", Noun, "domesticated")
+	frump := specificSense(t, "dog", Noun, "dull unattractive")
+	if dog.SemanticRichness() <= frump.SemanticRichness() {
+		t.Errorf("expected dog (%d) to be richer than frump (%d)", dog.SemanticRichness(), frump.SemanticRichness())
+	}
+	if !strings.Contains(dog.DumpStr(), fmt.Sprintf("Richness: %d\n", dog.SemanticRichness())) {
+		t.Errorf("dump lacks the richness:\n%s", dog.DumpStr())
+	}
+}
+
+func TestLookupSpecific(t *testing.T) {
+	river, err := wnInstance.LookupSpecific("bank", Noun, "River")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if !strings.Contains(river.Gloss(), "river") {
+		t.Errorf("unexpected sense of bank: %s", river.Gloss())
+	}
+
+	if _, err := wnInstance.LookupSpecific("bank", Noun, "money"); err == nil {
+		t.Errorf("expected an error when several senses match")
+	}
+	if _, err := wnInstance.LookupSpecific("bank", Noun, "xyzzy"); err == nil {
+		t.Errorf("expected an error when no sense matches")
+	}
+}
+
+func TestIterateParallel(t *testing.T) {
+	var count atomic.Int64
+	err := wnInstance.IterateParallel(PartOfSpeechList{Noun}, 4, func(l Lookup) error {
+		count.Add(1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("IterateParallel failed: %v", err)
+	}
+	if count.Load() != 82192 {
+		t.Errorf("expected 82192 nouns, got %d", count.Load())
+	}
+
+	stop := errors.New("stop")
+	count.Store(0)
+	err = wnInstance.IterateParallel(nil, 0, func(l Lookup) error {
+		if count.Add(1) == 100 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Errorf("expected the callback's error, got %v", err)
+	}
+	if count.Load() > 1000 {
+		t.Errorf("iteration not cancelled after error, visited %d synsets", count.Load())
+	}
+}
+
+func TestWordNotFound(t *testing.T) {
+	found, err := wnInstance.Lookup(Criteria{Matching: "xyzzyplugh"})
+	if err != nil || found == nil || len(found) != 0 {
+		t.Errorf("expected an empty, non-nil result and no error, got %v, %v", found, err)
+	}
+
+	if _, err := wnInstance.LookupSpecific("xyzzyplugh", Noun, "river"); !errors.Is(err, ErrWordNotFound) {
+		t.Errorf("expected ErrWordNotFound from LookupSpecific, got %v", err)
+	}
+	if _, err := wnInstance.LookupSpecific("bank", Noun, "xyzzy"); err == nil || errors.Is(err, ErrWordNotFound) {
+		t.Errorf("expected a non ErrWordNotFound error from LookupSpecific, got %v", err)
+	}
+	if _, err := wnInstance.Categorize("xyzzyplugh", []string{"animal"}); !errors.Is(err, ErrWordNotFound) {
+		t.Errorf("expected ErrWordNotFound from Categorize, got %v", err)
+	}
+	if _, err := wnInstance.Lookup(Criteria{}); err == nil || errors.Is(err, ErrWordNotFound) {
+		t.Errorf("expected an invalid criteria error, got %v", err)
+	}
+}
+
+func TestAddException(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 01 lemma 0 000 | a proposition used in proving another\n" +
+			"00000002 03 n 01 lemmas 0 000 | a made up plural\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+
+	if found, _ := h.Lookup(Criteria{Matching: "lemmata"}); len(found) != 0 {
+		t.Fatalf("lemmata shouldn't be known before adding an exception")
+	}
+
+	h.AddException(Noun, "Lemmata", "lemma")
+	h.AddException(Noun, "lemmas", "lemma")
+
+	if got := h.MorphWord("lemmata", Noun); got != "lemma" {
+		t.Errorf("MorphWord(lemmata) = %q; want lemma", got)
+	}
+	if got := h.MorphWord("lemmas", Noun); got != "lemma" {
+		t.Errorf("user exception should take priority over rules, MorphWord(lemmas) = %q", got)
+	}
+	if got := h.MorphWord("lemmata", Verb); got != "" {
+		t.Errorf("exception leaked into verbs: %q", got)
+	}
+	found, _ := h.Lookup(Criteria{Matching: "lemmata"})
+	if len(found) != 1 || found[0].Lemma() != "lemma" {
+		t.Errorf("expected lookup of lemmata to find lemma, got %v", found)
+	}
+}
+
+func TestLookupByOffset(t *testing.T) {
+	entity, err := wnInstance.LookupByOffset(Noun, 1740)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if entity.Word() != "entity" || entity.SynsetID() != "00001740-n" {
+		t.Errorf("unexpected synset at noun offset 1740: %s %s", entity.Word(), entity.SynsetID())
+	}
+
+	breathe, err := wnInstance.LookupByOffset(Verb, 1740)
+	if err != nil || breathe.Word() != "breathe" {
+		t.Errorf("unexpected synset at verb offset 1740: %v %v", breathe, err)
+	}
+
+	byID, err := wnInstance.LookupByID(entity.SynsetID())
+	if err != nil || !byID.Equal(entity) {
+		t.Errorf("LookupByID(%s) = %v, %v", entity.SynsetID(), byID, err)
+	}
+
+	handy, err := wnInstance.LookupByID("00019769-s")
+	if err != nil || handy.Word() != "handy" {
+		t.Errorf("expected satellite ids to be accepted, got %v, %v", handy, err)
+	}
+
+	if _, err := wnInstance.LookupByOffset(Noun, 1741); !errors.Is(err, ErrSynsetNotFound) {
+		t.Errorf("expected ErrSynsetNotFound for an offset in the middle of a line, got %v", err)
+	}
+	// dog's WordNet 3.0 offset falls in the middle of a 3.1 record
+	if dog, err := wnInstance.LookupByID("02084071-n"); !errors.Is(err, ErrSynsetNotFound) {
+		t.Errorf("expected ErrSynsetNotFound for a WordNet 3.0 id, got %v, %v", dog, err)
+	}
+	if dog, err := wnInstance.LookupByOffset(Noun, 2084071); !errors.Is(err, ErrSynsetNotFound) {
+		t.Errorf("expected ErrSynsetNotFound for a WordNet 3.0 offset, got %v, %v", dog, err)
+	}
+	for _, id := range []string{"", "00001740", "1740-n", "00001740-x", "00001740-nn"} {
+		if _, err := wnInstance.LookupByID(id); err == nil {
+			t.Errorf("expected an error for id %q", id)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		word     string
+		pos      PartOfSpeech
+		expected bool
+	}{
+		{"dog", Noun, true},
+		{"Dog", Noun, true},
+		{"ice  cream", Noun, true},
+		{"dog", Verb, true},
+		{"dog", Adverb, false},
+		{"dogs", Noun, false},
+		{"quickly", Adverb, true},
+		{"xyzzyplugh", Noun, false},
+	}
+
+	for _, tt := range tests {
+		if got := wnInstance.Contains(tt.word, tt.pos); got != tt.expected {
+			t.Errorf("Contains(%q, %v) = %v; want %v", tt.word, tt.pos, got, tt.expected)
+		}
+	}
+
+	if !wnInstance.ContainsAnyPOS("quickly") || wnInstance.ContainsAnyPOS("dogs") {
+		t.Errorf("unexpected ContainsAnyPOS results")
+	}
+}
+
+func TestIsValidWord(t *testing.T) {
+	for word, want := range map[string]bool{"dog": true, "dogs": true, "geese": true, "Barked": true, "ice cream": true, "Paris": true, "xyzzyplugh": false} {
+		if got := wnInstance.IsValidWord(word); got != want {
+			t.Errorf("IsValidWord(%q) = %v; want %v", word, got, want)
+		}
+	}
+
+	dir := writeDataDir(t, map[string]string{
+		"data.noun": "00000001 05 n 01 goose 0 000 | a bird\n" +
+			"00000002 06 n 01 china 0 000 | dishware\n" +
+			"00000003 15 n 01 China 0 000 | a country\n" +
+			"00000004 15 n 01 Paris 0 000 | a city\n" +
+			"00000005 13 n 01 ice_cream 0 000 | a frozen dessert\n",
+		"data.adj": "00000001 00 a 01 well-known 0 000 | widely known\n",
+		"noun.exc": "geese goose\n",
+	})
+	h, err := NewWithOptions(dir, Options{ValidWordsExcludeCollocations: true, ValidWordsExcludeProperNouns: true})
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	for word, want := range map[string]bool{"goose": true, "Geese": true, "china": true, "China": true, "Chinas": true, "paris": false, "ice cream": false, "ice_cream": false, "well-known": false} {
+		if got := h.IsValidWord(word); got != want {
+			t.Errorf("filtered IsValidWord(%q) = %v; want %v", word, got, want)
+		}
+	}
+}
+
+func TestRelatedWordsPOS(t *testing.T) {
+	beauty := specificSense(t, "beauty", Noun, "qualities")
+	got := beauty.RelatedWordsPOS(DerivationallyRelatedForm)
+	for _, want := range []WordPOS{{"beauteous", Adjective}, {"beautify", Verb}, {"beautician", Noun}} {
+		if !slices.Contains(got, want) {
+			t.Errorf("%v missing from %v", want, got)
+		}
+	}
+	if len(got) != len(beauty.RelatedWords(DerivationallyRelatedForm)) {
+		t.Errorf("expected one entry per related word, got %v", got)
+	}
+
+	// deduplicated on word and part of speech only
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 04 n 01 fishery 0 003 + 00000001 v 0000 + 00000002 n 0000 + 00000001 v 0101 | a place for catching fish\n" +
+			"00000002 13 n 01 fish 0 000 | the flesh of fish used as food\n",
+		"data.verb": "00000001 35 v 01 fish 0 000 | catch or try to catch fish\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	fishery := firstSense(t, h, "fishery", Noun)
+	if got, want := fishery.RelatedWordsPOS(DerivationallyRelatedForm), []WordPOS{{"fish", Verb}, {"fish", Noun}}; !slices.Equal(got, want) {
+		t.Errorf("RelatedWordsPOS = %v; want %v", got, want)
+	}
+}
+
+func TestRelatedWords(t *testing.T) {
+	found, err := wnInstance.Lookup(Criteria{Matching: "jab", POS: []PartOfSpeech{Noun}})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	var words []string
+	for _, f := range found {
+		words = append(words, f.RelatedWords(Hypernym)...)
+	}
+	// punch's synset also contains "clout" and "poke"
+	if !setContains(words, []string{"punch", "clout"}) {
+		t.Errorf("missing hypernym words for jab, got %v", words)
+	}
+
+	for _, f := range found {
+		ws := f.RelatedWords(Hypernym | Hyponym)
+		seen := map[string]bool{}
+		for _, w := range ws {
+			if seen[w] {
+				t.Errorf("duplicate word %q in %v", w, ws)
+			}
+			seen[w] = true
+		}
+	}
+}
+
+func TestLookupMatchingAny(t *testing.T) {
+	single, _ := wnInstance.Lookup(Criteria{Matching: "run", POS: []PartOfSpeech{Verb}})
+	found, err := wnInstance.Lookup(Criteria{MatchingAny: []string{"run", "runs", "running", "ran"}, POS: []PartOfSpeech{Verb}})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(found) != len(single) {
+		t.Errorf("inflections of run should share its %d senses, got %d", len(single), len(found))
+	}
+
+	found, err = wnInstance.Lookup(Criteria{Matching: "dog", MatchingAny: []string{"cat", "domestic dog"}, POS: []PartOfSpeech{Noun}})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	dogs, _ := wnInstance.Lookup(Criteria{Matching: "dog", POS: []PartOfSpeech{Noun}})
+	cats, _ := wnInstance.Lookup(Criteria{Matching: "cat", POS: []PartOfSpeech{Noun}})
+	if len(found) != len(dogs)+len(cats) {
+		t.Errorf("expected %d dog and cat senses, got %d", len(dogs)+len(cats), len(found))
+	}
+	ids := map[string]bool{}
+	for _, f := range found {
+		if f.POS() != Noun {
+			t.Errorf("POS filter not applied to %s", f.SynsetID())
+		}
+		if ids[f.SynsetID()] {
+			t.Errorf("duplicate synset %s", f.SynsetID())
+		}
+		ids[f.SynsetID()] = true
+	}
+}
+
+func TestRelatedFrom(t *testing.T) {
+	breathe, err := wnInstance.LookupByOffset(Verb, 1740)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	words := func(ls []Lookup) (ws []string) {
+		for _, l := range ls {
+			ws = append(ws, l.Word())
+		}
+		slices.Sort(ws)
+		return ws
+	}
+
+	if got, want := words(breathe.RelatedFrom("breathe", DerivationallyRelatedForm)), []string{"breather", "breathing"}; !slices.Equal(got, want) {
+		t.Errorf("derivations of breathe = %v; want %v", got, want)
+	}
+	if got, want := words(breathe.RelatedFrom("Respire", DerivationallyRelatedForm)), []string{"respiration", "respirator", "respiratory"}; !slices.Equal(got, want) {
+		t.Errorf("derivations of respire = %v; want %v", got, want)
+	}
+	if got := breathe.RelatedFrom("respire", Hypernym|Entailment); len(got) != 0 {
+		t.Errorf("semantic relations shouldn't be returned, got %v", words(got))
+	}
+	if got := breathe.RelatedFrom("dog", DerivationallyRelatedForm); got != nil {
+		t.Errorf("expected nothing for a non member, got %v", words(got))
+	}
+
+	// lexical relations also resolve for inflected searches
+	found, _ := wnInstance.Lookup(Criteria{Matching: "breathes", POS: []PartOfSpeech{Verb}})
+	for _, f := range found {
+		if f.Equal(breathe) {
+			if got := words(f.Related(DerivationallyRelatedForm)); !slices.Equal(got, []string{"breather", "breathing"}) {
+				t.Errorf("derivations of breathes = %v", got)
+			}
+		}
+	}
+}
+
+func TestBogusRelationTarget(t *testing.T) {
+	_, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 01 widget 0 001 + 00000002 n 0103 | a small gadget\n" +
+			"00000002 03 n 01 gizmo 0 000 | a gadget\n",
+	}))
+	if err == nil {
+		t.Errorf("expected an error for a relation to a missing word")
+	}
+}
+
+func TestCaseFold(t *testing.T) {
+	for _, q := range []string{"ıstanbul", "GLAß"} {
+		if found, _ := wnInstance.Lookup(Criteria{Matching: q}); len(found) != 0 {
+			t.Errorf("%s shouldn't be found with default folding", q)
+		}
+	}
+
+	turkish, err := NewWithOptions(sourceCodeRelPath(PathToWordnetDataFiles), Options{CaseFold: language.Turkish})
+	if err != nil {
+		t.Fatalf("can't load: %s", err)
+	}
+	for _, q := range []string{"ISTANBUL", "ıstanbul", "İstanbul", "GLAß"} {
+		if found, _ := turkish.Lookup(Criteria{Matching: q}); len(found) == 0 {
+			t.Errorf("expected Turkish folding to find %s", q)
+		}
+	}
+	if !turkish.Contains("ISTANBUL", Noun) {
+		t.Errorf("expected Turkish folding to apply to Contains")
+	}
+	if found, _ := turkish.Lookup(Criteria{Matching: "DOGS"}); len(found) == 0 {
+		t.Errorf("morphology should still apply with a case folding language")
+	}
+}
+
+func TestSynsetsWithSynonymCount(t *testing.T) {
+	singletons := wnInstance.SynsetsWithSynonymCount(Adverb, 1, 1)
+	large := wnInstance.SynsetsWithSynonymCount(Adverb, 3, 0)
+	all := wnInstance.SynsetsWithSynonymCount(Adverb, 0, 0)
+	if len(singletons) == 0 || len(large) == 0 {
+		t.Fatalf("expected results, got %d singletons and %d large synsets", len(singletons), len(large))
+	}
+	if len(all) != 3625 {
+		t.Errorf("expected every adverb without bounds, got %d", len(all))
+	}
+	for _, l := range singletons {
+		if len(l.Synonyms()) != 1 || l.POS() != Adverb {
+			t.Fatalf("%s doesn't belong among single member adverbs", l.SynsetID())
+		}
+	}
+	for _, l := range large {
+		if len(l.Synonyms()) < 3 {
+			t.Fatalf("%s has fewer than 3 members", l.SynsetID())
+		}
+	}
+	if two := wnInstance.SynsetsWithSynonymCount(Adverb, 2, 2); len(singletons)+len(two)+len(large) != len(all) {
+		t.Errorf("partitions don't add up")
+	}
+}
+
+func TestAntonymPairs(t *testing.T) {
+	pairs := map[[2]string]int{}
+	err := wnInstance.AntonymPairs(Adjective, func(a, b string) error {
+		pairs[[2]string{a, b}]++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	for _, want := range [][2]string{{"good", "bad"}, {"hot", "cold"}} {
+		n := pairs[want] + pairs[[2]string{want[1], want[0]}]
+		if n != 1 {
+			t.Errorf("pair %v reported %d times, want once", want, n)
+		}
+	}
+	for p, n := range pairs {
+		if n > 1 {
+			t.Errorf("pair %v reported %d times", p, n)
+		}
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	if err := wnInstance.AntonymPairs(Noun, func(a, b string) error { calls++; return stop }); err != stop || calls != 1 {
+		t.Errorf("expected to stop after one call with the callback's error, got %v after %d", err, calls)
+	}
+}
+
+func TestSharedSynsets(t *testing.T) {
+	shared := wnInstance.SharedSynsets("big", "large", Adjective)
+	if len(shared) == 0 {
+		t.Fatalf("big and large should share a synset")
+	}
+	for _, l := range shared {
+		if syn := l.Synonyms(); !setContains(syn, []string{"big", "large"}) {
+			t.Errorf("%s doesn't contain both words: %v", l.SynsetID(), syn)
+		}
+	}
+	if swapped := wnInstance.SharedSynsets("large", "big", Adjective); len(swapped) != len(shared) {
+		t.Errorf("not symmetric: %d vs %d", len(swapped), len(shared))
+	}
+	if got := wnInstance.SharedSynsets("big", "large", Verb); len(got) != 0 {
+		t.Errorf("expected no verb synsets, got %d", len(got))
+	}
+	if got := wnInstance.SharedSynsets("dog", "cat", Noun); len(got) != 0 {
+		t.Errorf("dog and cat aren't synonyms, got %v", got[0].Synonyms())
+	}
+}
+
+func TestPointerSymbols(t *testing.T) {
+	dog := wnInstance.PointerSymbols("dog", Noun)
+	if !setContains(dog, []string{"@", "~", "%p", "#m"}) || slices.Contains(dog, "") {
+		t.Errorf("unexpected pointer symbols for dog: %v", dog)
+	}
+	if !slices.IsSorted(dog) {
+		t.Errorf("pointer symbols not sorted: %v", dog)
+	}
+	if slices.Contains(dog, "!") {
+		t.Errorf("dog has no antonyms but got %v", dog)
+	}
+
+	if quickly := wnInstance.PointerSymbols("quickly", Adverb); !setContains(quickly, []string{"!", "\\"}) {
+		t.Errorf("unexpected pointer symbols for quickly: %v", quickly)
+	}
+	if got := wnInstance.PointerSymbols("xyzzyplugh", Noun); len(got) != 0 {
+		t.Errorf("expected no symbols for an unknown word, got %v", got)
+	}
+}
+
+func TestEmptyAndWhitespaceQueries(t *testing.T) {
+	for _, crit := range []Criteria{
+		{},
+		{Matching: ""},
+		{Matching: "  \t "},
+		{MatchingAny: []string{"", " "}},
+	} {
+		if _, err := wnInstance.Lookup(crit); !errors.Is(err, ErrEmptyQuery) {
+			t.Errorf("Lookup(%+v) = %v; want ErrEmptyQuery", crit, err)
+		}
+	}
+
+	plain, _ := wnInstance.Lookup(Criteria{Matching: "dog"})
+	padded, err := wnInstance.Lookup(Criteria{Matching: "  dog\t"})
+	if err != nil || len(padded) != len(plain) || len(plain) == 0 {
+		t.Errorf("surrounding whitespace should be ignored, got %d results (%v) vs %d", len(padded), err, len(plain))
+	}
+
+	spaced, _ := wnInstance.Lookup(Criteria{Matching: "ice cream"})
+	underscored, _ := wnInstance.Lookup(Criteria{Matching: " ice_cream "})
+	if len(spaced) == 0 || len(underscored) != len(spaced) {
+		t.Errorf("collocations with underscores should match, got %d vs %d", len(underscored), len(spaced))
+	}
+}
+
+func TestEmptySynsets(t *testing.T) {
+	h, err := New(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 01 widget 0 001 @ 00000002 n 0000 | a small gadget\n" +
+			"00000002 03 n 00 001 ~ 00000001 n 0000 | a hand edited synset that lost its members\n",
+	}))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	if s := h.Stats(); s.Synsets != 1 || s.EmptySynsets != 1 {
+		t.Errorf("expected one synset and one skipped, got %d and %d", s.Synsets, s.EmptySynsets)
+	}
+	widget := firstSense(t, h, "widget", Noun)
+	if rel := widget.Related(Hypernym); len(rel) != 0 {
+		t.Errorf("pointer to the empty synset kept: %v", rel)
+	}
+
+	// the zero Lookup doesn't panic
+	var zero Lookup
+	if zero.Lemma() != "" || zero.Word() != "" || zero.Gloss() != "" || zero.SynsetID() != "" {
+		t.Errorf("expected empty strings from the zero Lookup")
+	}
+	if len(zero.Synonyms())+len(zero.SynonymsByFrequency())+len(zero.Related(Hypernym|Antonym))+len(zero.RelatedWords(Hyponym)) != 0 {
+		t.Errorf("expected empty slices from the zero Lookup")
+	}
+	_ = zero.String()
+	_ = zero.DumpStr()
+	if d := zero.Depth(); d != 0 {
+		t.Errorf("expected depth 0 for the zero Lookup, got %d", d)
+	}
+	dog := firstSense(t, wnInstance, "dog", Noun)
+	for _, m := range []SimilarityMeasure{Path, WuPalmer, LeacockChodorow, Resnik, Lin, JiangConrath} {
+		if _, err := wnInstance.Similarity(zero, dog, m); err == nil {
+			t.Errorf("expected an error comparing the zero Lookup with %T", m)
+		}
+	}
+	if _, err := wnInstance.PathSimilarity(dog, zero); err == nil {
+		t.Error("expected an error from PathSimilarity with the zero Lookup")
+	}
+	if _, err := wnInstance.InformationContent(zero); err == nil {
+		t.Error("expected an error for the information content of the zero Lookup")
+	}
+}
+
+func BenchmarkIterateCount(b *testing.B) {
+	for range b.N {
+		n := 0
+		wnInstance.Iterate(nil, func(Lookup) error {
+			n++
+			return nil
+		})
+	}
+}
+
+func BenchmarkIterateLemmas(b *testing.B) {
+	for range b.N {
+		n := 0
+		wnInstance.Iterate(nil, func(l Lookup) error {
+			n += len(l.Synonyms())
+			return nil
+		})
+	}
+}
+
+func BenchmarkIterateRelations(b *testing.B) {
+	for range b.N {
+		n := 0
+		wnInstance.Iterate(nil, func(l Lookup) error {
+			n += len(l.Related(^Relation(0)))
+			return nil
+		})
+	}
+}
+
+func TestInverse(t *testing.T) {
+	for rel, want := range map[Relation]Relation{
+		Hypernym:            Hyponym,
+		InstanceHyponym:     InstanceHypernym,
+		PartMeronym:         PartHolonym,
+		ContainsDomainTopic: InDomainTopic,
+		Antonym:             Antonym,
+	} {
+		if got, ok := Inverse(rel); !ok || got != want {
+			t.Errorf("Inverse(%s) = %s, %v; want %s", rel.name(), got.name(), ok, want.name())
+		}
+	}
+	for _, rel := range []Relation{AlsoSee, Entailment, Cause, Pertainym, Hypernym | Hyponym, 0} {
+		if _, ok := Inverse(rel); ok {
+			t.Errorf("expected no inverse for %s", rel.name())
+		}
+	}
+
+	// every semantic pointer with an inverse has its reverse in the data
+	missing := map[Relation]int{}
+	wnInstance.Iterate(nil, func(l Lookup) error {
+		for _, rel := range l.cluster.relations {
+			inverse, ok := Inverse(rel.rel)
+			if ok && !slices.ContainsFunc(rel.target.relations, func(back semanticRelation) bool {
+				return back.rel == inverse && back.target == l.cluster
+			}) {
+				missing[rel.rel]++
+			}
+		}
+		return nil
+	})
+	for rel, n := range missing {
+		t.Errorf("%d %s pointers have no inverse", n, rel.name())
+	}
+}
+
+func TestParseRelation(t *testing.T) {
+	if len(AllRelations) != len(relationNames) {
+		t.Errorf("AllRelations has %d relations, relationNames %d", len(AllRelations), len(relationNames))
+	}
+	var all Relation
+	for _, r := range AllRelations {
+		if got, ok := ParseRelation(r.String()); !ok || got != r {
+			t.Errorf("ParseRelation(%q) = %v, %v", r.String(), got, ok)
+		}
+		if symbol, ok := relationSymbols[r]; ok {
+			if got, ok := ParseRelation(symbol); !ok || got != r {
+				t.Errorf("ParseRelation(%q) = %v, %v; want %v", symbol, got, ok, r)
+			}
+		}
+		all |= r
+	}
+	if all != VerbGroup<<1-1 {
+		t.Errorf("AllRelations misses relations: %#x", all)
+	}
+
+	for s, want := range map[string]Relation{"Also_See": AlsoSee, " part-meronym ": PartMeronym, "pertainym": Pertainym, "~i": InstanceHyponym} {
+		if got, ok := ParseRelation(s); !ok || got != want {
+			t.Errorf("ParseRelation(%q) = %v, %v; want %v", s, got, ok, want)
+		}
+	}
+	for _, s := range []string{"", "hyper", "hypernym|hyponym", "?"} {
+		if got, ok := ParseRelation(s); ok {
+			t.Errorf("ParseRelation(%q) = %v; want no relation", s, got)
+		}
+	}
+
+	for r, want := range map[Relation]string{Hypernym: "hypernym", Hypernym | Antonym: "antonym|hypernym", 0: "none", VerbGroup << 1: "Relation(0x8000000)"} {
+		if got := r.String(); got != want {
+			t.Errorf("String() = %q; want %q", got, want)
+		}
+	}
+}
+
+func TestResultOrdering(t *testing.T) {
+	found, err := wnInstance.Lookup(Criteria{Matching: "run"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < len(found); i++ {
+		prev, cur := found[i-1], found[i]
+		if prev.POS() > cur.POS() || (prev.POS() == cur.POS() && prev.SynsetID() >= cur.SynsetID()) {
+			t.Errorf("%s before %s", prev.Canonical(), cur.Canonical())
+		}
+	}
+
+	// with the sense index, senses are in sense number order, here the
+	// railway car first
+	fixture := maps.Clone(frequencyFixture)
+	fixture["index.sense"] = "car%1:06:00:: 00000001 2 40\n" +
+		"car%1:06:01:: 00000002 1 2\n"
+	h, err := New(writeDataDir(t, fixture))
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	cars, _ := h.Lookup(Criteria{Matching: "car"})
+	if len(cars) != 2 || cars[0].SynsetID() != "00000002-n" || cars[1].SynsetID() != "00000001-n" {
+		t.Errorf("car senses out of sense number order: %v", cars)
+	}
+
+	dog := specificSense(t, "dog", Noun, "domesticated")
+	if got, want := dog.Synonyms(), []string{"dog", "domestic dog", "Canis familiaris"}; !slices.Equal(got, want) {
+		t.Errorf("Synonyms() = %v; want %v", got, want)
+	}
+	var hypernyms []string
+	for _, r := range dog.Related(Hypernym) {
+		hypernyms = append(hypernyms, r.Word())
+	}
+	// sorted by id, domestic animal (01320032-n) before canine (02085998-n)
+	if want := []string{"domestic animal", "canine"}; !slices.Equal(hypernyms, want) {
+		t.Errorf("hypernyms of dog = %v; want %v", hypernyms, want)
+	}
+	for _, l := range []Lookup{dog, specificSense(t, "good", Adjective, "desirable or positive")} {
+		related := l.Related(^Relation(0))
+		if !slices.IsSortedFunc(related, func(a, b Lookup) int { return strings.Compare(a.SynsetID(), b.SynsetID()) }) {
+			t.Errorf("relations of %s aren't sorted by synset id", l.Canonical())
+		}
+	}
+}
+
+func TestVerbGroup(t *testing.T) {
+	onFoot := specificSense(t, "run", Verb, "using one's feet")
+	group := onFoot.Related(VerbGroup)
+	if len(group) != 1 || !strings.Contains(group[0].Gloss(), "cover by running") {
+		t.Fatalf("unexpected verb group for run (on foot): %v", group)
+	}
+	if back := group[0].Related(VerbGroup); !slices.ContainsFunc(back, func(l Lookup) bool { return l.SynsetID() == onFoot.SynsetID() }) {
+		t.Errorf("verb group isn't symmetric: %v", back)
+	}
+
+	operate := specificSense(t, "run", Verb, "direct or control")
+	if group := operate.Related(VerbGroup); len(group) != 0 {
+		t.Errorf("expected no verb group for run (operate), got %v", group)
+	}
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	_, err := NewWithOptions(writeDataDir(t, map[string]string{
+		"data.noun": "00000001 03 n 01 widget 0 002 @ 00000002 n 0000 ?x 00000002 n 0000 | a small gadget\n" +
+			"00000002 03 n 00 001 ~ 00000001 n 0000 | a hand edited synset that lost its members\n",
+		"README": "not a wordnet file\n",
+	}), Options{Logger: logger})
+	if err != nil {
+		t.Fatalf("can't load fixture: %s", err)
+	}
+	for _, want := range []string{
+		`level=WARN msg="data file not found" file=data.verb`,
+		`level=DEBUG msg="optional file not found" file=index.sense`,
+		`level=WARN msg="skipping synset without members" synset=00000002-n`,
+		`level=WARN msg="unknown pointer symbol" symbol=?x count=1`,
+		`level=DEBUG msg="ignoring file"`,
+		`level=DEBUG msg="loaded database" synsets=1 words=1`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("log lacks %q:\n%s", want, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), "file=data.noun") {
+		t.Errorf("data.noun reported missing:\n%s", buf.String())
+	}
+}